@@ -3,8 +3,10 @@ package appgofig
 import (
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"reflect"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -12,6 +14,9 @@ import (
 
 type ConfigReadMode string
 
+// fileEnvSuffix is appended to env keys to provide the path of a file containing the value instead
+const fileEnvSuffix = "_FILE"
+
 const (
 	ReadModeEnvOnly     ConfigReadMode = "env-only"
 	ReadModeYamlOnly    ConfigReadMode = "yaml-only"
@@ -19,11 +24,75 @@ const (
 	ReadModeYamlThenEnv ConfigReadMode = "yaml-env"
 )
 
+// YamlFile is one entry of a layered list of yaml files, see WithYamlFiles
+type YamlFile struct {
+	Path     string
+	Optional bool
+}
+
+// ReadReport contains information about how ReadConfig resolved the configuration, see WithReadReport
+type ReadReport struct {
+	// Fields maps every field name to the source its value came from
+	Fields map[string]FieldSource
+	// YamlSources maps every key read from yaml to the yaml file (or drop-in fragment) that provided its value
+	YamlSources map[string]string
+	// MountedSources maps every field read from a mounted directory to the file that provided its value
+	MountedSources map[string]string
+	// ResolvedFields maps every field whose value was a reference to the scheme of the resolver that resolved it
+	ResolvedFields map[string]string
+	// DecryptedFields contains every field whose value was decrypted from an ENC[...] value
+	DecryptedFields map[string]bool
+	// InterpolatedMaskedFields contains every field whose value interpolated a masked, resolved or decrypted field
+	InterpolatedMaskedFields map[string]bool
+	// ConfigFile is the yaml file found by discovery or referenced by the config path env var, empty if none was used
+	ConfigFile string
+	// YamlFiles contains every loaded yaml file (or "reader") in the order they were applied, empty if none was found
+	YamlFiles []string
+	// SearchedPaths contains every path checked while searching for a yaml file
+	SearchedPaths []string
+	// Warnings contains problems that did not prevent reading the config, e.g. falling back to a cached http config
+	Warnings []string
+}
+
+// YamlFileFound returns true if at least one yaml file (or reader) was loaded
+func (report *ReadReport) YamlFileFound() bool {
+	return len(report.YamlFiles) > 0
+}
+
 type AppGofigOptions struct {
-	ReadMode          ConfigReadMode
-	YamlFilePath      string
-	YamlFileRequested bool
-	NewDefaults       map[string]string
+	ReadMode                   ConfigReadMode
+	YamlFilePath               string
+	YamlFileRequested          bool
+	NewDefaults                map[string]string
+	DotenvFiles                []string
+	DotenvFilesRequested       bool
+	DotenvDisabled             bool
+	EnvLookup                  func(key string) (string, bool)
+	FS                         fs.FS
+	YamlFiles                  []YamlFile
+	YamlFilesRequested         bool
+	Profile                    string
+	ProfileRequested           bool
+	DropInDir                  string
+	DropInDirRequested         bool
+	Report                     *ReadReport
+	MountedDirs                []string
+	MountedDirsRequested       bool
+	Resolvers                  map[string]ValueResolver
+	BuiltinResolvers           bool
+	Interpolation              bool
+	DecryptionKey              []byte
+	DecryptionKeyFile          string
+	DecryptionKeyFileRequested bool
+	HTTPSource                 HTTPSource
+	HTTPSourceRequested        bool
+	YamlReaders                []io.Reader
+	DotenvReaders              []io.Reader
+	SearchDirs                 []string
+	ConfigNames                []string
+	ConfigPathEnv              string
+	ConfigPathEnvRequested     bool
+	YamlFileRequired           bool
 }
 
 type AppGofigOption func(*AppGofigOptions)
@@ -43,6 +112,159 @@ func WithYamlFile(filePath string) AppGofigOption {
 	}
 }
 
+// WithYamlFiles specifies an ordered list of yaml files, where later files overwrite values of earlier ones.
+// Missing optional files are skipped, missing non-optional files result in an error
+func WithYamlFiles(yamlFiles ...YamlFile) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.YamlFiles = yamlFiles
+		options.YamlFilesRequested = true
+	}
+}
+
+// WithProfile layers config.<profile>.yaml on top of every found yaml file (e.g. config.production.yaml on top of config.yaml).
+// Profile files are optional
+func WithProfile(profile string) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.Profile = profile
+		options.ProfileRequested = true
+	}
+}
+
+// WithDropInDir merges all yaml files within dirPath (e.g. /etc/myapp/conf.d) in lexical order on top of the other yaml files.
+// A missing directory is skipped
+func WithDropInDir(dirPath string) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.DropInDir = dirPath
+		options.DropInDirRequested = true
+	}
+}
+
+// WithMountedDirs reads values from directories containing one file per key, e.g. mounted Kubernetes ConfigMaps or Secrets.
+// Files are named after the field name or the env key, their content (without trailing newlines) is used as value.
+// Mounted directories are applied on top of all other sources, missing directories are skipped
+func WithMountedDirs(dirPaths ...string) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.MountedDirs = dirPaths
+		options.MountedDirsRequested = true
+	}
+}
+
+// WithResolver registers resolver for values like <scheme>://<reference>, e.g. vault://db/password.
+// It takes precedence over the built-in resolvers (see WithBuiltinResolvers). Values resolved by any resolver are masked
+// in the output when the ReadReport is passed using WithLogReport
+func WithResolver(scheme string, resolver ValueResolver) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		if options.Resolvers == nil {
+			options.Resolvers = make(map[string]ValueResolver)
+		}
+		options.Resolvers[scheme] = resolver
+	}
+}
+
+// WithBuiltinResolvers enables the built-in resolvers for file:// (content of a file) and env:// (an environment variable).
+// Without it, such values are used as they are
+func WithBuiltinResolvers() AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.BuiltinResolvers = true
+	}
+}
+
+// WithInterpolation enables ${VAR} and ${VAR:-fallback} references within config values of all sources.
+// VAR can be another config key (e.g. ${Host}) or an environment variable, config keys take precedence.
+// Use $${ for a literal ${
+func WithInterpolation() AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.Interpolation = true
+	}
+}
+
+// WithDecryptionKey decrypts ENC[...] values (see EncryptValue) using key, which has to be 16, 24 or 32 bytes long.
+// Without a key, ENC[...] values are kept as they are. Decrypted values are masked in the output
+// when the ReadReport is passed using WithLogReport
+func WithDecryptionKey(key []byte) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.DecryptionKey = key
+	}
+}
+
+// WithDecryptionKeyFile decrypts ENC[...] values using the base64 encoded key within the file at filePath.
+// Like other secret files, it is always read from the local disk, even when using WithFS
+func WithDecryptionKeyFile(filePath string) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.DecryptionKeyFile = filePath
+		options.DecryptionKeyFileRequested = true
+	}
+}
+
+// WithHTTPSource fetches a flat yaml or json config from a remote endpoint, layered on top of the yaml files.
+// ETags are honored and the last known good config is used from the cache file if the endpoint is not available
+func WithHTTPSource(source HTTPSource) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.HTTPSource = source
+		options.HTTPSourceRequested = true
+	}
+}
+
+// WithYamlReader reads a flat yaml config from reader (e.g. os.Stdin), layered on top of the yaml files.
+// Using a reader disables the discovery of default yaml files. The reader is consumed by the first ReadConfig call,
+// a Watcher buffers its content for every reload
+func WithYamlReader(reader io.Reader) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.YamlReaders = append(options.YamlReaders, reader)
+	}
+}
+
+// WithJSONReader reads a flat json config from reader, just like WithYamlReader
+func WithJSONReader(reader io.Reader) AppGofigOption {
+	// json is valid yaml
+	return WithYamlReader(reader)
+}
+
+// WithDotenvReader reads dotenv content from reader, layered on top of the dotenv files.
+// The reader is consumed by the first ReadConfig call, a Watcher buffers its content for every reload
+func WithDotenvReader(reader io.Reader) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.DotenvReaders = append(options.DotenvReaders, reader)
+	}
+}
+
+// WithSearchDirs sets the directories searched for yaml files in order, the first hit is used (default: ".", "config").
+// See ExecutableDir, UserConfigDir and SystemConfigDir for common directories
+func WithSearchDirs(dirs ...string) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.SearchDirs = dirs
+	}
+}
+
+// WithConfigNames sets the file names looked for within the search directories (default: "config.yml", "config.yaml")
+func WithConfigNames(names ...string) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.ConfigNames = names
+	}
+}
+
+// WithConfigPathEnv uses the yaml file referenced by the environment variable envKey (e.g. APP_CONFIG) instead of searching for one
+func WithConfigPathEnv(envKey string) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.ConfigPathEnv = envKey
+		options.ConfigPathEnvRequested = true
+	}
+}
+
+// WithRequiredYamlFile makes ReadConfig fail if no yaml file is found when using a yaml based read mode
+func WithRequiredYamlFile() AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.YamlFileRequired = true
+	}
+}
+
+// WithReadReport fills report with information about how the configuration was resolved
+func WithReadReport(report *ReadReport) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.Report = report
+	}
+}
+
 // WithNewDefaults adds new default values to use
 func WithNewDefaults(newDefaults map[string]string) AppGofigOption {
 	return func(options *AppGofigOptions) {
@@ -50,28 +272,109 @@ func WithNewDefaults(newDefaults map[string]string) AppGofigOption {
 	}
 }
 
+// WithDotenvFiles specifies which dotenv files to load instead of ./.env
+// Later files overwrite earlier ones, the actual environment always takes precedence over all of them.
+// Missing files are skipped, but files that exist and cannot be parsed result in an error
+func WithDotenvFiles(filePaths ...string) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.DotenvFiles = filePaths
+		options.DotenvFilesRequested = true
+	}
+}
+
+// WithoutDotenv disables loading of dotenv files entirely
+func WithoutDotenv() AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.DotenvDisabled = true
+	}
+}
+
+// WithEnvLookup replaces os.LookupEnv for resolving environment keys, e.g. for isolated tests
+func WithEnvLookup(envLookup func(key string) (string, bool)) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.EnvLookup = envLookup
+	}
+}
+
+// WithEnv uses envMap instead of the process environment for resolving environment keys
+func WithEnv(envMap map[string]string) AppGofigOption {
+	return WithEnvLookup(func(key string) (string, bool) {
+		value, ok := envMap[key]
+		return value, ok
+	})
+}
+
+// WithFS reads all config files (yaml, dotenv) from fileSystem instead of the local disk, e.g. from an embed.FS.
+// File paths are resolved relative to the root of fileSystem. Secret files (e.g. referenced by <KEY>_FILE,
+// WithDecryptionKeyFile or within WithMountedDirs) are still read from disk
+func WithFS(fileSystem fs.FS) AppGofigOption {
+	return func(options *AppGofigOptions) {
+		options.FS = fileSystem
+	}
+}
+
 // ReadConfig takes your targetConfig struct, applies defaults and then applies values according to the readMode
 // Using yamlFile, you can specify a yaml file to read from. If not specified, one of ./(config/)config.y(a)ml is used
 func ReadConfig(targetConfig any, optionList ...AppGofigOption) error {
+	_, _, err := readConfig(targetConfig, optionList)
+	return err
+}
+
+// readConfig implements ReadConfig and additionally returns the read layers and the report.
+// The layers are returned as soon as they were read, even if a later step fails
+func readConfig(targetConfig any, optionList []AppGofigOption) ([]configLayer, *ReadReport, error) {
 	if targetConfig == nil {
-		return fmt.Errorf("targetConfig must not be nil")
+		return nil, nil, fmt.Errorf("targetConfig must not be nil")
 	}
 
 	if v := reflect.ValueOf(targetConfig); v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
-		return fmt.Errorf("targetConfig has to point to a struct")
+		return nil, nil, fmt.Errorf("targetConfig has to point to a struct")
 	}
 
 	// check if only the supported config types are present
 	if err := onlyContainsSupportedTypes(targetConfig); err != nil {
-		return fmt.Errorf("targetConfig not valid: %w", err)
+		return nil, nil, fmt.Errorf("targetConfig not valid: %w", err)
+	}
+
+	if err := checkMaskTags(targetConfig); err != nil {
+		return nil, nil, fmt.Errorf("targetConfig not valid: %w", err)
 	}
 
 	// apply the options
 	gofigOptions := &AppGofigOptions{
-		ReadMode:          ReadModeEnvThenYaml,
-		YamlFilePath:      "",
-		YamlFileRequested: false,
-		NewDefaults:       nil,
+		ReadMode:                   ReadModeEnvThenYaml,
+		YamlFilePath:               "",
+		YamlFileRequested:          false,
+		NewDefaults:                nil,
+		DotenvFiles:                nil,
+		DotenvFilesRequested:       false,
+		DotenvDisabled:             false,
+		EnvLookup:                  os.LookupEnv,
+		FS:                         nil,
+		YamlFiles:                  nil,
+		YamlFilesRequested:         false,
+		Profile:                    "",
+		ProfileRequested:           false,
+		DropInDir:                  "",
+		DropInDirRequested:         false,
+		Report:                     nil,
+		MountedDirs:                nil,
+		MountedDirsRequested:       false,
+		Resolvers:                  nil,
+		BuiltinResolvers:           false,
+		Interpolation:              false,
+		DecryptionKey:              nil,
+		DecryptionKeyFile:          "",
+		DecryptionKeyFileRequested: false,
+		HTTPSource:                 HTTPSource{},
+		HTTPSourceRequested:        false,
+		YamlReaders:                nil,
+		DotenvReaders:              nil,
+		SearchDirs:                 []string{".", "config"},
+		ConfigNames:                []string{"config.yml", "config.yaml"},
+		ConfigPathEnv:              "",
+		ConfigPathEnvRequested:     false,
+		YamlFileRequired:           false,
 	}
 
 	for _, opt := range optionList {
@@ -80,87 +383,267 @@ func ReadConfig(targetConfig any, optionList ...AppGofigOption) error {
 
 	if gofigOptions.YamlFileRequested {
 		if len(gofigOptions.YamlFilePath) == 0 {
-			return fmt.Errorf("the yaml file path cannot be empty")
+			return nil, nil, fmt.Errorf("the yaml file path cannot be empty")
 		}
 
 		if gofigOptions.ReadMode == ReadModeEnvOnly {
-			return fmt.Errorf("when using the ReadModeEnvOnly, no yaml file shall be specified")
+			return nil, nil, fmt.Errorf("when using the ReadModeEnvOnly, no yaml file shall be specified")
 		}
 	}
 
-	// apply the default values first
-	if gofigOptions.NewDefaults == nil {
-		if err := applyDefaultsToConfig(targetConfig); err != nil {
-			return fmt.Errorf("unable to apply defaults: %w", err)
+	if gofigOptions.YamlFilesRequested {
+		if len(gofigOptions.YamlFiles) == 0 {
+			return nil, nil, fmt.Errorf("the yaml file list cannot be empty")
 		}
-	} else {
-		if err := applyStringMapToConfig(targetConfig, gofigOptions.NewDefaults); err != nil {
-			return fmt.Errorf("unable to apply new defaults: %w", err)
+
+		if slices.ContainsFunc(gofigOptions.YamlFiles, func(yamlFile YamlFile) bool { return len(yamlFile.Path) == 0 }) {
+			return nil, nil, fmt.Errorf("the yaml file paths cannot be empty")
+		}
+
+		if gofigOptions.YamlFileRequested {
+			return nil, nil, fmt.Errorf("WithYamlFile and WithYamlFiles cannot be combined")
+		}
+
+		if gofigOptions.ReadMode == ReadModeEnvOnly {
+			return nil, nil, fmt.Errorf("when using the ReadModeEnvOnly, no yaml files shall be specified")
+		}
+	}
+
+	if gofigOptions.ProfileRequested {
+		if len(gofigOptions.Profile) == 0 {
+			return nil, nil, fmt.Errorf("the profile cannot be empty")
+		}
+
+		if gofigOptions.ReadMode == ReadModeEnvOnly {
+			return nil, nil, fmt.Errorf("when using the ReadModeEnvOnly, no profile shall be specified")
+		}
+	}
+
+	if gofigOptions.DropInDirRequested {
+		if len(gofigOptions.DropInDir) == 0 {
+			return nil, nil, fmt.Errorf("the drop-in directory path cannot be empty")
+		}
+
+		if gofigOptions.ReadMode == ReadModeEnvOnly {
+			return nil, nil, fmt.Errorf("when using the ReadModeEnvOnly, no drop-in directory shall be specified")
 		}
 	}
 
-	// read the config according to the read mode
-	switch gofigOptions.ReadMode {
-	case ReadModeEnvOnly:
-		// Only read from environment
-		if err := applyEnvironmentToConfig(targetConfig); err != nil {
-			return fmt.Errorf("could not read config values from env: %w", err)
+	if gofigOptions.MountedDirsRequested {
+		if len(gofigOptions.MountedDirs) == 0 {
+			return nil, nil, fmt.Errorf("the mounted directory list cannot be empty")
 		}
-	case ReadModeYamlOnly:
-		// Only read from yaml file
-		if err := applyYamlToConfig(targetConfig, gofigOptions); err != nil {
-			return fmt.Errorf("could not read config values from yaml: %w", err)
+
+		if slices.Contains(gofigOptions.MountedDirs, "") {
+			return nil, nil, fmt.Errorf("the mounted directory paths cannot be empty")
 		}
-	case ReadModeEnvThenYaml:
-		// first read from environment, then overwrite existing stuff with yaml
-		if err := applyEnvironmentToConfig(targetConfig); err != nil {
-			return fmt.Errorf("could not read config values from env: %w", err)
+	}
+
+	for scheme, resolver := range gofigOptions.Resolvers {
+		if len(scheme) == 0 || strings.Contains(scheme, "://") {
+			return nil, nil, fmt.Errorf("invalid resolver scheme %q", scheme)
 		}
-		if err := applyYamlToConfig(targetConfig, gofigOptions); err != nil {
-			return fmt.Errorf("could not read config values from yaml: %w", err)
+
+		if resolver == nil {
+			return nil, nil, fmt.Errorf("the resolver for scheme %s cannot be nil", scheme)
 		}
-	case ReadModeYamlThenEnv:
-		// first read from yaml, then overwrite existing stuff from environment
-		if err := applyYamlToConfig(targetConfig, gofigOptions); err != nil {
-			return fmt.Errorf("could not read config values from yaml: %w", err)
+	}
+
+	if gofigOptions.DecryptionKeyFileRequested {
+		if len(gofigOptions.DecryptionKeyFile) == 0 {
+			return nil, nil, fmt.Errorf("the decryption key file path cannot be empty")
 		}
-		if err := applyEnvironmentToConfig(targetConfig); err != nil {
-			return fmt.Errorf("could not read config values from env: %w", err)
+
+		if gofigOptions.DecryptionKey != nil {
+			return nil, nil, fmt.Errorf("WithDecryptionKey and WithDecryptionKeyFile cannot be combined")
 		}
-	default:
-		return fmt.Errorf("invalid read mode %s", gofigOptions.ReadMode)
+	}
+
+	if gofigOptions.HTTPSourceRequested {
+		if len(gofigOptions.HTTPSource.URL) == 0 {
+			return nil, nil, fmt.Errorf("the http source url cannot be empty")
+		}
+
+		if gofigOptions.ReadMode == ReadModeEnvOnly {
+			return nil, nil, fmt.Errorf("when using the ReadModeEnvOnly, no http source shall be specified")
+		}
+	}
+
+	if len(gofigOptions.YamlReaders) > 0 {
+		if slices.Contains(gofigOptions.YamlReaders, nil) {
+			return nil, nil, fmt.Errorf("the yaml reader cannot be nil")
+		}
+
+		if gofigOptions.ReadMode == ReadModeEnvOnly {
+			return nil, nil, fmt.Errorf("when using the ReadModeEnvOnly, no yaml reader shall be specified")
+		}
+	}
+
+	if len(gofigOptions.DotenvReaders) > 0 {
+		if slices.Contains(gofigOptions.DotenvReaders, nil) {
+			return nil, nil, fmt.Errorf("the dotenv reader cannot be nil")
+		}
+
+		if gofigOptions.DotenvDisabled {
+			return nil, nil, fmt.Errorf("dotenv readers cannot be specified while dotenv loading is disabled")
+		}
+
+		if gofigOptions.ReadMode == ReadModeYamlOnly {
+			return nil, nil, fmt.Errorf("when using the ReadModeYamlOnly, no dotenv reader shall be specified")
+		}
+	}
+
+	if gofigOptions.YamlFileRequired && gofigOptions.ReadMode == ReadModeEnvOnly {
+		return nil, nil, fmt.Errorf("when using the ReadModeEnvOnly, no yaml file can be required")
+	}
+
+	if len(gofigOptions.SearchDirs) == 0 {
+		return nil, nil, fmt.Errorf("the search directory list cannot be empty")
+	}
+
+	if len(gofigOptions.ConfigNames) == 0 || slices.Contains(gofigOptions.ConfigNames, "") {
+		return nil, nil, fmt.Errorf("the config names cannot be empty")
+	}
+
+	if gofigOptions.ConfigPathEnvRequested && len(gofigOptions.ConfigPathEnv) == 0 {
+		return nil, nil, fmt.Errorf("the config path env key cannot be empty")
+	}
+
+	if gofigOptions.EnvLookup == nil {
+		return nil, nil, fmt.Errorf("the env lookup function cannot be nil")
+	}
+
+	if gofigOptions.DotenvFilesRequested {
+		if len(gofigOptions.DotenvFiles) == 0 {
+			return nil, nil, fmt.Errorf("the dotenv file list cannot be empty")
+		}
+
+		if slices.Contains(gofigOptions.DotenvFiles, "") {
+			return nil, nil, fmt.Errorf("the dotenv file paths cannot be empty")
+		}
+
+		if gofigOptions.DotenvDisabled {
+			return nil, nil, fmt.Errorf("dotenv files cannot be specified while dotenv loading is disabled")
+		}
+
+		if gofigOptions.ReadMode == ReadModeYamlOnly {
+			return nil, nil, fmt.Errorf("when using the ReadModeYamlOnly, no dotenv files shall be specified")
+		}
+	}
+
+	if gofigOptions.Report == nil {
+		gofigOptions.Report = &ReadReport{}
+	}
+	*gofigOptions.Report = ReadReport{
+		Fields:                   make(map[string]FieldSource),
+		YamlSources:              make(map[string]string),
+		MountedSources:           make(map[string]string),
+		ResolvedFields:           make(map[string]string),
+		DecryptedFields:          make(map[string]bool),
+		InterpolatedMaskedFields: make(map[string]bool),
+	}
+
+	// read all sources into layers and merge them according to the read mode
+	layers, err := loadConfigLayers(targetConfig, gofigOptions)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	mergedValues, mergedSources := mergeConfigLayers(layers)
+	recordFieldSources(targetConfig, mergedSources, gofigOptions.Report)
+
+	processValue, err := newFieldValueProcessor(gofigOptions)
+	if err != nil {
+		return layers, gofigOptions.Report, err
+	}
+
+	if gofigOptions.Interpolation {
+		// the interpolator processes every field before its value is substituted into other fields
+		interpolationReferences, err := interpolateValues(targetConfig, mergedValues, gofigOptions, processValue)
+		if err != nil {
+			return layers, gofigOptions.Report, fmt.Errorf("unable to interpolate values: %w", err)
+		}
+
+		recordInterpolatedMaskedFields(targetConfig, interpolationReferences, gofigOptions.Report)
+	} else if err := processFieldValues(targetConfig, mergedValues, processValue); err != nil {
+		return layers, gofigOptions.Report, err
+	}
+
+	if err := applyStringMapToConfig(targetConfig, mergedValues, gofigOptions.Report); err != nil {
+		return layers, gofigOptions.Report, fmt.Errorf("unable to apply config values: %w", err)
 	}
 
 	// check if all required keys are non-empty
 	if err := checkForEmptyRequiredFields(targetConfig); err != nil {
-		return fmt.Errorf("missing required fields: %w", err)
+		return layers, gofigOptions.Report, fmt.Errorf("missing required fields: %w", err)
 	}
 
-	return nil
+	return layers, gofigOptions.Report, nil
 }
 
-// LogToConsole logs the actual configuration to the console
-func LogConfig(targetConfig any, out io.Writer) {
-	fmt.Fprint(out, "### AppGofig Configuration Start ###\n")
+// fieldValueProcessor decrypts and resolves the raw value of a single field, see newFieldValueProcessor
+type fieldValueProcessor func(fieldName string, value string) (string, error)
 
-	t := reflect.TypeOf(targetConfig).Elem()
-	v := reflect.ValueOf(targetConfig).Elem()
+// newFieldValueProcessor returns a fieldValueProcessor which first decrypts ENC[...] values and then resolves
+// references like file:///run/secrets/db according to gofigOptions
+func newFieldValueProcessor(gofigOptions *AppGofigOptions) (fieldValueProcessor, error) {
+	key, err := loadDecryptionKey(gofigOptions)
+	if err != nil {
+		return nil, fmt.Errorf("unable to decrypt values: %w", err)
+	}
 
+	return func(fieldName string, value string) (string, error) {
+		decryptedValue, err := decryptFieldValue(fieldName, value, key, gofigOptions.Report)
+		if err != nil {
+			return "", fmt.Errorf("unable to decrypt values: %w", err)
+		}
+
+		resolvedValue, err := resolveFieldValue(fieldName, decryptedValue, gofigOptions)
+		if err != nil {
+			return "", fmt.Errorf("unable to resolve value references: %w", err)
+		}
+
+		return resolvedValue, nil
+	}, nil
+}
+
+// processFieldValues applies processValue to the value of every field of targetConfig within mergedValues
+func processFieldValues(targetConfig any, mergedValues map[string]string, processValue fieldValueProcessor) error {
+	t := reflect.TypeOf(targetConfig).Elem()
 	for k := 0; k < t.NumField(); k++ {
 		field := t.Field(k)
-		val := v.Field(k)
 
-		key := field.Name
-		stringVal := readStringFromValue(val)
+		value, ok := mergedValues[field.Name]
+		if !ok {
+			continue
+		}
 
-		if shouldBeMasked(field) {
-			stringVal = fmt.Sprintf("[Masked - Length: %d]", len(stringVal))
+		processedValue, err := processValue(field.Name, value)
+		if err != nil {
+			return err
 		}
 
-		fmt.Fprintf(out, "#| %s : %s\n", key, stringVal)
+		mergedValues[field.Name] = processedValue
 	}
 
-	fmt.Fprint(out, "### AppGofig Configuration End ###\n")
+	return nil
+}
+
+// LogToConsole logs the actual configuration to the console, as text by default. Use WithLogFormat for other formats
+// and WithLogReport to mask resolved and decrypted values and show the source of every field
+func LogConfig(targetConfig any, out io.Writer, logOptionList ...LogOption) {
+	logOptions := newLogOptions(logOptionList)
+
+	entries := readLogEntries(targetConfig, logOptions)
+
+	switch logOptions.Format {
+	case LogFormatJSON:
+		writeJSONLog(out, entries)
+	case LogFormatLogfmt:
+		writeLogfmtLog(out, entries)
+	default:
+		writeTextLog(out, entries)
+	}
 }
 
 // CreateMarkdownFile creates a simple markdown table with information about the provided config inputs
@@ -172,14 +655,17 @@ func WriteToMarkdownFile(targetConfig any, configDescriptions map[string]string,
 	sb.WriteString("# Default Configuration\n")
 	fmt.Fprintf(&sb, "*Generated %s*\n\n", currentTimeString)
 
-	sb.WriteString("| YAML Key | ENV Key | Type | Required | Default | Description |\n")
-	sb.WriteString("|---|---|---|---|---|---|\n")
+	sb.WriteString("| YAML Key | ENV Key | Type | Required | Restart Required | Default | Description |\n")
+	sb.WriteString("|---|---|---|---|---|---|---|\n")
 
 	t := reflect.TypeOf(targetConfig).Elem()
 	for k := 0; k < t.NumField(); k++ {
 		field := t.Field(k)
 		yamlKey := field.Name
 		envKey := field.Tag.Get("env")
+		if shouldBeMasked(field) {
+			envKey = fieldEnvKey(field) + " (or " + fieldEnvKey(field) + fileEnvSuffix + ")"
+		}
 
 		defaultValue := field.Tag.Get("default")
 		description := configDescriptions[yamlKey]
@@ -189,8 +675,13 @@ func WriteToMarkdownFile(targetConfig any, configDescriptions map[string]string,
 			required = "yes"
 		}
 
+		restartRequired := "no"
+		if requiresRestart(field) {
+			restartRequired = "yes"
+		}
+
 		// Write Markdown row
-		sb.WriteString("| " + yamlKey + " | " + envKey + " | " + field.Type.Kind().String() + " | " + required + " | " + defaultValue + " | " + description + " |\n")
+		sb.WriteString("| " + yamlKey + " | " + envKey + " | " + field.Type.Kind().String() + " | " + required + " | " + restartRequired + " | " + defaultValue + " | " + description + " |\n")
 	}
 
 	markdownFile, err := os.Create(markdownFilePath)
@@ -222,6 +713,9 @@ func WriteToYamlExampleFile(targetConfig any, configDescriptions map[string]stri
 
 		defaultValue := field.Tag.Get("default")
 		description := configDescriptions[field.Name]
+		if shouldBeMasked(field) {
+			description += " (can also be provided as file via " + fieldEnvKey(field) + fileEnvSuffix + ")"
+		}
 
 		required := " - optional"
 		if isRequiredField(field) {
@@ -285,16 +779,42 @@ func checkForEmptyRequiredFields(targetConfig any) error {
 	return nil
 }
 
-// shouldBeMasked returns true if the field has a "mask" tag
+// isMaskedField returns true if the field has a "mask" tag or its value was resolved from a reference, decrypted
+// or interpolated from such a field according to report
+func isMaskedField(report *ReadReport, field reflect.StructField) bool {
+	if _, resolved := report.ResolvedFields[field.Name]; resolved {
+		return true
+	}
+
+	if report.DecryptedFields[field.Name] || report.InterpolatedMaskedFields[field.Name] {
+		return true
+	}
+
+	return shouldBeMasked(field)
+}
+
+// shouldBeMasked returns true if the field has a "mask" tag which is either true or a mask strategy
 func shouldBeMasked(field reflect.StructField) bool {
 	maskTag, hasMaskTag := field.Tag.Lookup("mask")
 	if !hasMaskTag {
 		return false
 	}
 
-	boolVal, _ := strconv.ParseBool(maskTag)
+	if boolVal, err := strconv.ParseBool(maskTag); err == nil {
+		return boolVal
+	}
+
+	// anything else is a strategy, unknown ones are fully redacted
+	return true
+}
+
+// fieldEnvKey returns the key used for environment variables, which is the "env" tag if present or the field name otherwise
+func fieldEnvKey(field reflect.StructField) string {
+	if fieldEnv, hasEnv := field.Tag.Lookup("env"); hasEnv {
+		return fieldEnv
+	}
 
-	return boolVal
+	return field.Name
 }
 
 // isRequiredField checks if field has a tag "req" and returns true only
@@ -312,3 +832,16 @@ func isRequiredField(field reflect.StructField) bool {
 		return boolVal
 	}
 }
+
+// requiresRestart checks if field has a tag "reload" which is false according to strconv.ParseBool,
+// meaning a changed value cannot be applied by a hot reload
+func requiresRestart(field reflect.StructField) bool {
+	reloadVal, ok := field.Tag.Lookup("reload")
+	if !ok {
+		return false
+	}
+
+	boolVal, err := strconv.ParseBool(reloadVal)
+
+	return err == nil && !boolVal
+}
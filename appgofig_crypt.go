@@ -0,0 +1,112 @@
+package appgofig
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+const (
+	encryptedValuePrefix = "ENC["
+	encryptedValueSuffix = "]"
+)
+
+// EncryptValue encrypts plaintext with key using AES-GCM and returns it as ENC[...] value to be used within config files.
+// key has to be 16, 24 or 32 bytes long
+func EncryptValue(key []byte, plaintext string) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("unable to create nonce: %w", err)
+	}
+
+	sealed := aead.Seal(nonce, nonce, []byte(plaintext), nil)
+
+	return encryptedValuePrefix + base64.StdEncoding.EncodeToString(sealed) + encryptedValueSuffix, nil
+}
+
+// decryptFieldValue decrypts the value of the field fieldName if it is an ENC[...] value, other values are returned as they are.
+// Without a key, decryption is disabled and all values are returned as they are.
+// Decrypted fields are recorded in the report so they can be masked in the output
+func decryptFieldValue(fieldName string, value string, key []byte, report *ReadReport) (string, error) {
+	if key == nil || !isEncryptedValue(value) {
+		return value, nil
+	}
+
+	decryptedValue, err := decryptValue(key, strings.TrimSpace(value))
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt field %s: %w", fieldName, err)
+	}
+
+	report.DecryptedFields[fieldName] = true
+	return decryptedValue, nil
+}
+
+// loadDecryptionKey returns the decryption key of gofigOptions, reading the base64 encoded key file if necessary.
+// Returns nil if no key was provided
+func loadDecryptionKey(gofigOptions *AppGofigOptions) ([]byte, error) {
+	if !gofigOptions.DecryptionKeyFileRequested {
+		return gofigOptions.DecryptionKey, nil
+	}
+
+	data, err := readSecretFile(gofigOptions.DecryptionKeyFile)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read decryption key file (%q): %w", gofigOptions.DecryptionKeyFile, err)
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(data)))
+	if err != nil {
+		return nil, fmt.Errorf("decryption key file (%q) does not contain a base64 encoded key: %w", gofigOptions.DecryptionKeyFile, err)
+	}
+
+	return key, nil
+}
+
+// isEncryptedValue returns true if value uses the ENC[...] envelope
+func isEncryptedValue(value string) bool {
+	value = strings.TrimSpace(value)
+	return strings.HasPrefix(value, encryptedValuePrefix) && strings.HasSuffix(value, encryptedValueSuffix)
+}
+
+// decryptValue decrypts a single ENC[...] value
+func decryptValue(key []byte, value string) (string, error) {
+	aead, err := newAEAD(key)
+	if err != nil {
+		return "", err
+	}
+
+	encoded := strings.TrimSuffix(strings.TrimPrefix(value, encryptedValuePrefix), encryptedValueSuffix)
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("invalid encoding: %w", err)
+	}
+
+	if len(sealed) < aead.NonceSize() {
+		return "", fmt.Errorf("encrypted value is too short")
+	}
+
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt value: %w", err)
+	}
+
+	return string(plaintext), nil
+}
+
+// newAEAD creates an AES-GCM cipher for key
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key: %w", err)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,93 @@
+package appgofig
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestEncryptedValues(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	encrypted, err := EncryptValue(key, "topSecretPassword")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(encrypted, "ENC[") || strings.Contains(encrypted, "topSecretPassword") {
+		t.Fatalf("unexpected encrypted value %s", encrypted)
+	}
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("StringVal: " + encrypted + "\n")},
+	}
+
+	// the key file is read from disk, not from the config file system
+	keyFile := filepath.Join(t.TempDir(), "keyfile")
+	if err := os.WriteFile(keyFile, []byte(base64.StdEncoding.EncodeToString(key)+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	report := &ReadReport{}
+	cfg := &TestConfig{}
+	if err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithReadReport(report), WithDecryptionKey(key)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.StringVal != "topSecretPassword" {
+		t.Errorf("expected StringVal=topSecretPassword, got %s", cfg.StringVal)
+	}
+
+	var sb strings.Builder
+	LogConfig(cfg, &sb, WithLogReport(report))
+	if strings.Contains(sb.String(), "topSecretPassword") {
+		t.Errorf("expected decrypted value to be masked, got: %s", sb.String())
+	}
+
+	cfg = &TestConfig{}
+	if err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithDecryptionKeyFile(keyFile)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.StringVal != "topSecretPassword" {
+		t.Errorf("expected StringVal=topSecretPassword, got %s", cfg.StringVal)
+	}
+}
+
+func TestEncryptedValuesErrors(t *testing.T) {
+	t.Parallel()
+
+	key := []byte("0123456789abcdef0123456789abcdef")
+	encrypted, _ := EncryptValue(key, "topSecretPassword")
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("StringVal: " + encrypted + "\n")},
+	}
+
+	// decryption is only enabled by providing a key, other ENC[...] values are kept as they are
+	cfg := &TestConfig{}
+	if err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.StringVal != encrypted {
+		t.Errorf("expected StringVal to be kept without decryption key, got %s", cfg.StringVal)
+	}
+
+	wrongKey := []byte("fedcba9876543210fedcba9876543210")
+	if err := ReadConfig(&TestConfig{}, WithFS(fileSystem), WithEnv(map[string]string{}), WithDecryptionKey(wrongKey)); err == nil {
+		t.Fatal("expected error for wrong decryption key, got none")
+	}
+
+	if _, err := EncryptValue([]byte("short"), "value"); err == nil {
+		t.Fatal("expected error for invalid key length, got none")
+	}
+
+	if err := ReadConfig(&TestConfig{}, WithDecryptionKey(key), WithDecryptionKeyFile("keyfile")); err == nil {
+		t.Fatal("expected error when combining key and key file, got none")
+	}
+}
@@ -0,0 +1,107 @@
+package appgofig
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"slices"
+)
+
+// FieldChange describes a field whose value differs between two configs
+type FieldChange struct {
+	Field  string
+	EnvKey string
+	// OldValue and NewValue are empty for masked fields, only the fact that they changed is reported
+	OldValue string
+	NewValue string
+	Masked   bool
+}
+
+// FieldChanges is a list of changed fields in the order of the config struct
+type FieldChanges []FieldChange
+
+// Contains returns true if the field with the given name changed
+func (changes FieldChanges) Contains(field string) bool {
+	return slices.ContainsFunc(changes, func(change FieldChange) bool {
+		return change.Field == field
+	})
+}
+
+// Diff returns every field whose value differs between oldConfig and newConfig, which have to be pointers to structs of the same type.
+// A nil pointer counts as a config with all values empty. Masked fields are reported without values, pass the reports of
+// both configs using WithLogReport to mask resolved and decrypted values as well. Returns an error if the types differ
+func Diff(oldConfig any, newConfig any, logOptionList ...LogOption) (FieldChanges, error) {
+	oldType := reflect.TypeOf(oldConfig)
+	if oldType == nil || oldType.Kind() != reflect.Pointer || oldType.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("configs have to be pointers to structs, got %T", oldConfig)
+	}
+	if oldType != reflect.TypeOf(newConfig) {
+		return nil, fmt.Errorf("configs have to be of the same type, got %T and %T", oldConfig, newConfig)
+	}
+
+	return diffConfigs(oldConfig, newConfig, newLogOptions(logOptionList)), nil
+}
+
+// diffConfigs implements Diff without checking the types of the configs
+func diffConfigs(oldConfig any, newConfig any, logOptions *LogOptions) FieldChanges {
+	oldValue := reflect.ValueOf(oldConfig)
+	newValue := reflect.ValueOf(newConfig)
+
+	changes := FieldChanges{}
+	if oldValue.IsNil() && newValue.IsNil() {
+		return changes
+	}
+
+	t := oldValue.Type().Elem()
+	for k := 0; k < t.NumField(); k++ {
+		field := t.Field(k)
+
+		oldString := ""
+		if !oldValue.IsNil() {
+			oldString = readStringFromValue(oldValue.Elem().Field(k))
+		}
+
+		newString := ""
+		if !newValue.IsNil() {
+			newString = readStringFromValue(newValue.Elem().Field(k))
+		}
+
+		if !oldValue.IsNil() && !newValue.IsNil() && oldString == newString {
+			continue
+		}
+
+		change := FieldChange{
+			Field:  field.Name,
+			EnvKey: fieldEnvKey(field),
+			Masked: logOptions.isMaskedField(field),
+		}
+
+		if !change.Masked {
+			change.OldValue = oldString
+			change.NewValue = newString
+		}
+
+		changes = append(changes, change)
+	}
+
+	return changes
+}
+
+// LogDiff logs the changes in the style of LogConfig
+func LogDiff(changes []FieldChange, out io.Writer) {
+	fmt.Fprint(out, "### AppGofig Configuration Diff Start ###\n")
+	writeFieldChanges(out, changes)
+	fmt.Fprint(out, "### AppGofig Configuration Diff End ###\n")
+}
+
+// writeFieldChanges writes one line per change, masked fields are only reported as changed
+func writeFieldChanges(out io.Writer, changes []FieldChange) {
+	for _, change := range changes {
+		if change.Masked {
+			fmt.Fprintf(out, "#| %s : changed\n", change.Field)
+			continue
+		}
+
+		fmt.Fprintf(out, "#| %s : %s -> %s\n", change.Field, change.OldValue, change.NewValue)
+	}
+}
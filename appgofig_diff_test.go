@@ -0,0 +1,94 @@
+package appgofig
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDiff(t *testing.T) {
+	t.Parallel()
+
+	oldConfig := &TestConfig{StringVal: "same", IntVal: 1, BoolVal: true, SecretVal: "secret1"}
+	newConfig := &TestConfig{StringVal: "same", IntVal: 2, BoolVal: false, SecretVal: "secret2"}
+
+	changes, err := Diff(oldConfig, newConfig)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedChanges := []FieldChange{
+		{Field: "IntVal", EnvKey: "TEST_INT", OldValue: "1", NewValue: "2"},
+		{Field: "BoolVal", EnvKey: "TEST_BOOL", OldValue: "true", NewValue: "false"},
+		{Field: "SecretVal", EnvKey: "TEST_SECRET", Masked: true},
+	}
+	if len(changes) != len(expectedChanges) {
+		t.Fatalf("expected %d changes, got %+v", len(expectedChanges), changes)
+	}
+	for i, expectedChange := range expectedChanges {
+		if changes[i] != expectedChange {
+			t.Errorf("expected %+v, got %+v", expectedChange, changes[i])
+		}
+	}
+
+	if changes, _ := Diff(oldConfig, oldConfig); len(changes) != 0 {
+		t.Errorf("expected no changes for equal configs, got %+v", changes)
+	}
+
+	var out bytes.Buffer
+	LogDiff(changes, &out)
+
+	expectedOutput := "### AppGofig Configuration Diff Start ###\n" +
+		"#| IntVal : 1 -> 2\n" +
+		"#| BoolVal : true -> false\n" +
+		"#| SecretVal : changed\n" +
+		"### AppGofig Configuration Diff End ###\n"
+	if out.String() != expectedOutput {
+		t.Errorf("expected:\n%s\ngot:\n%s", expectedOutput, out.String())
+	}
+}
+
+func TestDiffMasksResolvedValues(t *testing.T) {
+	t.Parallel()
+
+	type resolvedConfig struct {
+		Password string
+	}
+
+	oldConfig := &resolvedConfig{Password: "plain"}
+	newConfig := &resolvedConfig{}
+	env := map[string]string{"Password": "env://DB_PASSWORD", "DB_PASSWORD": "resolvedSecret"}
+	report := &ReadReport{}
+	err := ReadConfig(newConfig, WithEnv(env), WithBuiltinResolvers(), WithReadReport(report), WithoutDotenv(), WithReadMode(ReadModeEnvOnly))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// without the report, only the tags are known
+	if changes, _ := Diff(oldConfig, newConfig); len(changes) != 1 || changes[0].Masked {
+		t.Errorf("expected untagged value to not be masked without report, got %+v", changes)
+	}
+
+	changes, err := Diff(oldConfig, newConfig, WithLogReport(report))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(changes) != 1 || !changes[0].Masked || changes[0].NewValue != "" {
+		t.Errorf("expected resolved value to be masked, got %+v", changes)
+	}
+}
+
+func TestDiffWithDifferentTypes(t *testing.T) {
+	t.Parallel()
+
+	if _, err := Diff(&TestConfig{}, &watchTestConfig{}); err == nil {
+		t.Error("expected error for different types, got none")
+	}
+
+	if _, err := Diff(&TestConfig{}, nil); err == nil {
+		t.Error("expected error for untyped nil, got none")
+	}
+
+	if _, err := Diff(TestConfig{}, TestConfig{}); err == nil {
+		t.Error("expected error for non-pointers, got none")
+	}
+}
@@ -0,0 +1,102 @@
+package appgofig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Explanation lists every candidate value of every config field, see Explain
+type Explanation struct {
+	Fields []FieldExplanation
+}
+
+// FieldExplanation lists the candidate values of a single field in ascending precedence
+type FieldExplanation struct {
+	Field      string
+	Candidates []Candidate
+}
+
+// Candidate is the raw value one source provided for a field
+type Candidate struct {
+	Source FieldSource
+	// Value is the raw value before any interpolation, decryption or resolving, masked for masked fields
+	Value string
+	// Applied is true for the candidate that won, all others were overridden
+	Applied bool
+}
+
+// Explain runs the same resolution as ReadConfig without modifying targetConfig and returns every candidate value
+// of every field in the order the sources were applied. If resolving fails after all sources were read,
+// the explanation is returned together with the error
+func Explain(targetConfig any, optionList ...AppGofigOption) (*Explanation, error) {
+	if targetConfig == nil {
+		return nil, fmt.Errorf("targetConfig must not be nil")
+	}
+
+	if v := reflect.ValueOf(targetConfig); v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return nil, fmt.Errorf("targetConfig has to point to a struct")
+	}
+
+	// resolve into a fresh struct, so targetConfig stays untouched
+	explainConfig := reflect.New(reflect.TypeOf(targetConfig).Elem()).Interface()
+
+	layers, report, err := readConfig(explainConfig, optionList)
+	if layers == nil {
+		return nil, err
+	}
+
+	explanation := &Explanation{}
+
+	t := reflect.TypeOf(explainConfig).Elem()
+	for k := 0; k < t.NumField(); k++ {
+		field := t.Field(k)
+		fieldExplanation := FieldExplanation{Field: field.Name}
+
+		for _, layer := range layers {
+			value, ok := layer.values[field.Name]
+			if !ok {
+				continue
+			}
+
+			if isMaskedField(report, field) {
+				value = maskValue(field, value)
+			}
+
+			fieldExplanation.Candidates = append(fieldExplanation.Candidates, Candidate{
+				Source: layer.sources[field.Name],
+				Value:  value,
+			})
+		}
+
+		// later layers overwrite earlier ones, so the last candidate wins
+		if len(fieldExplanation.Candidates) > 0 {
+			fieldExplanation.Candidates[len(fieldExplanation.Candidates)-1].Applied = true
+		}
+
+		explanation.Fields = append(explanation.Fields, fieldExplanation)
+	}
+
+	return explanation, err
+}
+
+// String renders the explanation as markdown table
+func (explanation *Explanation) String() string {
+	var sb strings.Builder
+
+	sb.WriteString("| Field | Source | Value | Status |\n")
+	sb.WriteString("|---|---|---|---|\n")
+
+	for _, fieldExplanation := range explanation.Fields {
+		for _, candidate := range fieldExplanation.Candidates {
+			status := "overridden"
+			if candidate.Applied {
+				status = "applied"
+			}
+
+			fmt.Fprintf(&sb, "| %s | %s | %s | %s |\n", fieldExplanation.Field, candidate.Source, candidate.Value, status)
+		}
+	}
+
+	return sb.String()
+}
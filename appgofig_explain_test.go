@@ -0,0 +1,113 @@
+package appgofig
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestExplain(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("IntVal: 1\nSecretVal: yamlSecret\n")},
+	}
+	env := map[string]string{"TEST_INT": "2", "TEST_SECRET": "envSecret"}
+
+	cfg := &TestConfig{}
+	explanation, err := Explain(cfg, WithFS(fileSystem), WithEnv(env), WithReadMode(ReadModeYamlThenEnv))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.IntVal != 0 {
+		t.Errorf("expected targetConfig to stay untouched, got IntVal=%d", cfg.IntVal)
+	}
+
+	intExplanation := explanation.Fields[1]
+	if intExplanation.Field != "IntVal" || len(intExplanation.Candidates) != 3 {
+		t.Fatalf("expected 3 candidates for IntVal, got %+v", intExplanation)
+	}
+
+	expectedCandidates := []Candidate{
+		{Source: FieldSource{Source: "default"}, Value: "42", Applied: false},
+		{Source: FieldSource{Source: "yaml", Location: "config.yaml", Line: 1}, Value: "1", Applied: false},
+		{Source: FieldSource{Source: "env", Location: "TEST_INT"}, Value: "2", Applied: true},
+	}
+	for i, expectedCandidate := range expectedCandidates {
+		if intExplanation.Candidates[i] != expectedCandidate {
+			t.Errorf("expected candidate %+v, got %+v", expectedCandidate, intExplanation.Candidates[i])
+		}
+	}
+
+	// the other read mode swaps the winner
+	explanation, err = Explain(cfg, WithFS(fileSystem), WithEnv(env), WithReadMode(ReadModeEnvThenYaml))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	table := explanation.String()
+	if !strings.Contains(table, "| IntVal | yaml config.yaml:1 | 1 | applied |") {
+		t.Errorf("expected yaml value to be applied, got:\n%s", table)
+	}
+	if !strings.Contains(table, "| IntVal | env TEST_INT | 2 | overridden |") {
+		t.Errorf("expected env value to be overridden, got:\n%s", table)
+	}
+	if strings.Contains(table, "envSecret") || strings.Contains(table, "yamlSecret") {
+		t.Errorf("expected secrets to be masked, got:\n%s", table)
+	}
+}
+
+func TestExplainWithError(t *testing.T) {
+	t.Parallel()
+
+	explanation, err := Explain(&TestConfig{}, WithFS(fstest.MapFS{}), WithEnv(map[string]string{"TEST_STRING": ""}), WithReadMode(ReadModeEnvOnly))
+	if err == nil {
+		t.Fatal("expected error for empty required field, got none")
+	}
+
+	if explanation == nil || !strings.Contains(explanation.String(), "| StringVal | env TEST_STRING |  | applied |") {
+		t.Errorf("expected explanation despite the error, got %v", explanation)
+	}
+
+	if _, err := Explain(TestConfig{}); err == nil {
+		t.Fatal("expected error for non-pointer, got none")
+	}
+}
+
+func TestExplainListsEveryFile(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config.yaml":            {Data: []byte("IntVal: 1\n")},
+		"config.production.yaml": {Data: []byte("IntVal: 2\n")},
+		".env":                   {Data: []byte("TEST_INT=3\n")},
+		"local.env":              {Data: []byte("TEST_INT=4\n")},
+	}
+
+	explanation, err := Explain(&TestConfig{}, WithFS(fileSystem), WithProfile("production"), WithDotenvFiles(".env", "local.env"),
+		WithEnv(map[string]string{"TEST_INT": "5"}), WithReadMode(ReadModeYamlThenEnv))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedCandidates := []Candidate{
+		{Source: FieldSource{Source: "default"}, Value: "42", Applied: false},
+		{Source: FieldSource{Source: "yaml", Location: "config.yaml", Line: 1}, Value: "1", Applied: false},
+		{Source: FieldSource{Source: "yaml", Location: "config.production.yaml", Line: 1}, Value: "2", Applied: false},
+		{Source: FieldSource{Source: "dotenv", Location: ".env"}, Value: "3", Applied: false},
+		{Source: FieldSource{Source: "dotenv", Location: "local.env"}, Value: "4", Applied: false},
+		{Source: FieldSource{Source: "env", Location: "TEST_INT"}, Value: "5", Applied: true},
+	}
+
+	intExplanation := explanation.Fields[1]
+	if len(intExplanation.Candidates) != len(expectedCandidates) {
+		t.Fatalf("expected %d candidates for IntVal, got %+v", len(expectedCandidates), intExplanation)
+	}
+
+	for i, expectedCandidate := range expectedCandidates {
+		if intExplanation.Candidates[i] != expectedCandidate {
+			t.Errorf("expected candidate %+v, got %+v", expectedCandidate, intExplanation.Candidates[i])
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package appgofig
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// defaultHTTPTimeout is used for HTTPSource requests if no timeout is specified
+const defaultHTTPTimeout = 10 * time.Second
+
+// HTTPSource describes a remote endpoint providing a flat yaml or json config, see WithHTTPSource
+type HTTPSource struct {
+	// URL of the endpoint
+	URL string
+	// Client used for the request, defaults to http.DefaultClient
+	Client *http.Client
+	// Timeout of the whole request, defaults to 10 seconds
+	Timeout time.Duration
+	// CacheFile stores the last known good response on disk. It is used if the endpoint
+	// responds with 304 Not Modified or cannot be reached. Optional
+	CacheFile string
+}
+
+// httpCacheEntry is the content of HTTPSource.CacheFile
+type httpCacheEntry struct {
+	ETag string `json:"etag"`
+	Body string `json:"body"`
+}
+
+// readHTTPSource fetches the config of the http source into a layer, falling back to the cache file if the endpoint is not available
+func readHTTPSource(gofigOptions *AppGofigOptions) (configLayer, error) {
+	source := gofigOptions.HTTPSource
+
+	cacheEntry, err := readHTTPCache(source.CacheFile)
+	if err != nil {
+		return configLayer{}, err
+	}
+
+	body, etag, err := fetchHTTPSource(source, cacheEntry)
+	if err == nil {
+		httpLayer, parseErr := parseConfigData(body, "http", source.URL)
+		if parseErr == nil {
+			if err := writeHTTPCache(source.CacheFile, httpCacheEntry{ETag: etag, Body: string(body)}); err != nil {
+				gofigOptions.Report.Warnings = append(gofigOptions.Report.Warnings, err.Error())
+			}
+
+			return httpLayer, nil
+		}
+
+		err = fmt.Errorf("unable to parse response of %s: %w", source.URL, parseErr)
+	}
+
+	if cacheEntry == nil {
+		return configLayer{}, err
+	}
+
+	// an unchanged config is still served by the endpoint, otherwise the last known good config is used as fallback
+	sourceKind := "http"
+	if !errors.Is(err, errHTTPNotModified) {
+		sourceKind = "http-cache"
+	}
+
+	httpLayer, parseErr := parseConfigData([]byte(cacheEntry.Body), sourceKind, source.URL)
+	if parseErr != nil {
+		return configLayer{}, fmt.Errorf("unable to parse http cache file (%q): %w", source.CacheFile, parseErr)
+	}
+
+	if sourceKind == "http-cache" {
+		gofigOptions.Report.Warnings = append(gofigOptions.Report.Warnings, fmt.Sprintf("using cached config of %s: %v", source.URL, err))
+	}
+
+	return httpLayer, nil
+}
+
+// errHTTPNotModified signals that the cached response is still valid
+var errHTTPNotModified = errors.New("not modified")
+
+// fetchHTTPSource requests the config of source and returns the body and its ETag.
+// If cacheEntry has an ETag, it is sent as If-None-Match and errHTTPNotModified is returned for a 304 response
+func fetchHTTPSource(source HTTPSource, cacheEntry *httpCacheEntry) ([]byte, string, error) {
+	timeout := source.Timeout
+	if timeout <= 0 {
+		timeout = defaultHTTPTimeout
+	}
+
+	client := source.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, source.URL, nil)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to create request for %s: %w", source.URL, err)
+	}
+	request.Header.Set("Accept", "application/yaml, application/json")
+
+	if cacheEntry != nil && len(cacheEntry.ETag) > 0 {
+		request.Header.Set("If-None-Match", cacheEntry.ETag)
+	}
+
+	response, err := client.Do(request)
+	if err != nil {
+		return nil, "", fmt.Errorf("unable to request %s: %w", source.URL, err)
+	}
+	defer response.Body.Close()
+
+	switch response.StatusCode {
+	case http.StatusOK:
+		body, err := io.ReadAll(response.Body)
+		if err != nil {
+			return nil, "", fmt.Errorf("unable to read response of %s: %w", source.URL, err)
+		}
+
+		return body, response.Header.Get("ETag"), nil
+	case http.StatusNotModified:
+		if cacheEntry == nil {
+			return nil, "", fmt.Errorf("%s responded with 304 Not Modified, but there is no cached config", source.URL)
+		}
+
+		return nil, "", errHTTPNotModified
+	default:
+		return nil, "", fmt.Errorf("%s responded with unexpected status %s", source.URL, response.Status)
+	}
+}
+
+// readHTTPCache reads the cache file. Returns nil if there is no cache file
+func readHTTPCache(cacheFile string) (*httpCacheEntry, error) {
+	if len(cacheFile) == 0 {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(filepath.Clean(cacheFile))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read http cache file (%q): %w", cacheFile, err)
+	}
+
+	cacheEntry := &httpCacheEntry{}
+	if err := json.Unmarshal(data, cacheEntry); err != nil {
+		return nil, fmt.Errorf("unable to parse http cache file (%q): %w", cacheFile, err)
+	}
+
+	return cacheEntry, nil
+}
+
+// writeHTTPCache replaces the cache file with cacheEntry, does nothing if no cache file is used
+func writeHTTPCache(cacheFile string, cacheEntry httpCacheEntry) error {
+	if len(cacheFile) == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(cacheEntry)
+	if err != nil {
+		return fmt.Errorf("unable to encode http cache: %w", err)
+	}
+
+	// write to a temporary file first, so the last known good config is never corrupted
+	tmpFile := cacheFile + ".tmp"
+	if err := os.WriteFile(filepath.Clean(tmpFile), data, 0o600); err != nil {
+		return fmt.Errorf("unable to write http cache file (%q): %w", tmpFile, err)
+	}
+
+	if err := os.Rename(tmpFile, cacheFile); err != nil {
+		return fmt.Errorf("unable to replace http cache file (%q): %w", cacheFile, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,101 @@
+package appgofig
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"testing/fstest"
+)
+
+func TestHTTPSource(t *testing.T) {
+	t.Parallel()
+
+	var requests atomic.Int32
+	var notModified atomic.Int32
+	var available atomic.Bool
+	available.Store(true)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		if !available.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"IntVal": 5000, "StringVal": "remote"}`))
+	}))
+	defer server.Close()
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("IntVal: 1\nBoolVal: false\n")},
+	}
+	source := HTTPSource{URL: server.URL, Client: server.Client(), CacheFile: t.TempDir() + "/cache.json"}
+
+	readAndCheck := func() *ReadReport {
+		report := &ReadReport{}
+		cfg := &TestConfig{}
+		if err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithHTTPSource(source), WithReadReport(report)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if cfg.IntVal != 5000 {
+			t.Errorf("expected IntVal=5000, got %d", cfg.IntVal)
+		}
+		if cfg.StringVal != "remote" {
+			t.Errorf("expected StringVal=remote, got %s", cfg.StringVal)
+		}
+		if cfg.BoolVal != false {
+			t.Errorf("expected BoolVal=false, got %v", cfg.BoolVal)
+		}
+
+		return report
+	}
+
+	// fresh response
+	readAndCheck()
+
+	// cached response via ETag
+	report := readAndCheck()
+	if len(report.Warnings) != 0 {
+		t.Errorf("expected no warnings, got %v", report.Warnings)
+	}
+	if report.Fields["IntVal"].Source != "http" {
+		t.Errorf("expected IntVal from http, got %v", report.Fields["IntVal"])
+	}
+	if notModified.Load() != 1 {
+		t.Errorf("expected one 304 response, got %d", notModified.Load())
+	}
+
+	// endpoint down, last known good config is used
+	available.Store(false)
+	report = readAndCheck()
+	if len(report.Warnings) != 1 {
+		t.Errorf("expected a warning about using the cached config, got %v", report.Warnings)
+	}
+	if report.Fields["IntVal"] != (FieldSource{Source: "http-cache", Location: server.URL, Line: 1}) {
+		t.Errorf("expected IntVal from http-cache, got %v", report.Fields["IntVal"])
+	}
+
+	// endpoint down without cache
+	source.CacheFile = ""
+	if err := ReadConfig(&TestConfig{}, WithFS(fileSystem), WithEnv(map[string]string{}), WithHTTPSource(source)); err == nil {
+		t.Fatal("expected error for unavailable endpoint without cache, got none")
+	}
+
+	if requests.Load() != 4 {
+		t.Errorf("expected 4 requests, got %d", requests.Load())
+	}
+
+	if err := ReadConfig(&TestConfig{}, WithReadMode(ReadModeEnvOnly), WithHTTPSource(source)); err == nil {
+		t.Fatal("expected error when combining http source with ReadModeEnvOnly, got none")
+	}
+}
@@ -0,0 +1,223 @@
+package appgofig
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// interpolator expands ${VAR} and ${VAR:-fallback} references within config values.
+// VAR can be another config key or an environment variable, config keys take precedence.
+// The expanded value of every field is decrypted and resolved before it is substituted into other values
+type interpolator struct {
+	values       map[string]string
+	fields       map[string]bool
+	processValue fieldValueProcessor
+	lookupEnv    func(key string) (string, bool)
+	resolved     map[string]string
+	visiting     map[string]bool
+	// references maps every config key to the config keys its value references directly
+	references map[string][]string
+	// expanding is the stack of config keys currently being expanded
+	expanding []string
+}
+
+// interpolateValues expands all references within the values of mergedValues that belong to a field of targetConfig
+// and applies processValue to them. Returns the config keys referenced by each key, see recordInterpolatedMaskedFields
+func interpolateValues(targetConfig any, mergedValues map[string]string, gofigOptions *AppGofigOptions, processValue fieldValueProcessor) (map[string][]string, error) {
+	ip := &interpolator{
+		values:       mergedValues,
+		fields:       make(map[string]bool),
+		processValue: processValue,
+		lookupEnv:    gofigOptions.EnvLookup,
+		resolved:     make(map[string]string),
+		visiting:     make(map[string]bool),
+		references:   make(map[string][]string),
+	}
+
+	t := reflect.TypeOf(targetConfig).Elem()
+	for k := 0; k < t.NumField(); k++ {
+		ip.fields[t.Field(k).Name] = true
+	}
+	expandedValues := make(map[string]string)
+
+	for k := 0; k < t.NumField(); k++ {
+		field := t.Field(k)
+		if _, ok := mergedValues[field.Name]; !ok {
+			continue
+		}
+
+		expandedValue, err := ip.expandKey(field.Name)
+		if err != nil {
+			return nil, fmt.Errorf("unable to interpolate field %s: %w", field.Name, err)
+		}
+
+		expandedValues[field.Name] = expandedValue
+	}
+
+	// only write back after all fields are expanded, as other keys are read from mergedValues
+	for key, expandedValue := range expandedValues {
+		mergedValues[key] = expandedValue
+	}
+
+	return ip.references, nil
+}
+
+// recordInterpolatedMaskedFields marks every field of targetConfig within report whose value interpolated a masked,
+// resolved or decrypted field, directly or through other keys. Otherwise its value would expose the secret when logged
+func recordInterpolatedMaskedFields(targetConfig any, references map[string][]string, report *ReadReport) {
+	t := reflect.TypeOf(targetConfig).Elem()
+
+	var referencesMaskedField func(key string, visited map[string]bool) bool
+	referencesMaskedField = func(key string, visited map[string]bool) bool {
+		for _, referencedKey := range references[key] {
+			if visited[referencedKey] {
+				continue
+			}
+			visited[referencedKey] = true
+
+			if field, ok := t.FieldByName(referencedKey); ok && isMaskedField(report, field) {
+				return true
+			}
+
+			if referencesMaskedField(referencedKey, visited) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	for k := 0; k < t.NumField(); k++ {
+		field := t.Field(k)
+		if referencesMaskedField(field.Name, make(map[string]bool)) {
+			report.InterpolatedMaskedFields[field.Name] = true
+		}
+	}
+}
+
+// expandKey returns the expanded value of a config key while detecting cyclic references
+func (ip *interpolator) expandKey(key string) (string, error) {
+	if expandedValue, ok := ip.resolved[key]; ok {
+		return expandedValue, nil
+	}
+
+	if ip.visiting[key] {
+		return "", fmt.Errorf("cyclic reference to %s", key)
+	}
+
+	ip.visiting[key] = true
+	ip.expanding = append(ip.expanding, key)
+	expandedValue, err := ip.expand(ip.values[key])
+	ip.expanding = ip.expanding[:len(ip.expanding)-1]
+	delete(ip.visiting, key)
+
+	if err != nil {
+		return "", err
+	}
+
+	// keys which are no field are neither decrypted nor resolved, like without interpolation
+	if ip.fields[key] {
+		expandedValue, err = ip.processValue(key, expandedValue)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	ip.resolved[key] = expandedValue
+	return expandedValue, nil
+}
+
+// expand replaces all references within input. $${ can be used to write a literal ${
+func (ip *interpolator) expand(input string) (string, error) {
+	var sb strings.Builder
+
+	for i := 0; i < len(input); {
+		if strings.HasPrefix(input[i:], "$${") {
+			sb.WriteString("${")
+			i += 3
+			continue
+		}
+
+		if !strings.HasPrefix(input[i:], "${") {
+			sb.WriteByte(input[i])
+			i++
+			continue
+		}
+
+		end := closingBraceIndex(input, i+2)
+		if end < 0 {
+			// the value is not part of the error, as it might be a secret
+			return "", fmt.Errorf("unterminated reference within %s", ip.expanding[len(ip.expanding)-1])
+		}
+
+		expandedReference, err := ip.expandReference(input[i+2 : end])
+		if err != nil {
+			return "", err
+		}
+
+		sb.WriteString(expandedReference)
+		i = end + 1
+	}
+
+	return sb.String(), nil
+}
+
+// expandReference expands the content of a single reference like VAR or VAR:-fallback
+func (ip *interpolator) expandReference(reference string) (string, error) {
+	name, fallback, hasFallback := strings.Cut(reference, ":-")
+	name = strings.TrimSpace(name)
+	if len(name) == 0 {
+		return "", fmt.Errorf("empty reference within %s", ip.expanding[len(ip.expanding)-1])
+	}
+
+	value, defined, err := ip.lookup(name)
+	if err != nil {
+		return "", err
+	}
+
+	// like in shells, the fallback is used for undefined and empty values
+	if defined && (len(value) > 0 || !hasFallback) {
+		return value, nil
+	}
+
+	if hasFallback {
+		return ip.expand(fallback)
+	}
+
+	return "", fmt.Errorf("undefined reference ${%s}", name)
+}
+
+// lookup returns the expanded value of a config key or the value of an environment variable
+func (ip *interpolator) lookup(name string) (string, bool, error) {
+	if _, isConfigKey := ip.values[name]; isConfigKey {
+		currentKey := ip.expanding[len(ip.expanding)-1]
+		ip.references[currentKey] = append(ip.references[currentKey], name)
+
+		expandedValue, err := ip.expandKey(name)
+		return expandedValue, true, err
+	}
+
+	envVal, hasEnvVal := ip.lookupEnv(name)
+	return envVal, hasEnvVal, nil
+}
+
+// closingBraceIndex returns the index of the brace closing a reference starting at start, respecting nested references.
+// Returns -1 if there is none
+func closingBraceIndex(input string, start int) int {
+	depth := 0
+	for i := start; i < len(input); i++ {
+		switch {
+		case strings.HasPrefix(input[i:], "${"):
+			depth++
+			i++
+		case input[i] == '}':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+
+	return -1
+}
@@ -0,0 +1,151 @@
+package appgofig
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+type InterpolationConfig struct {
+	Host   string `default:"localhost"`
+	Port   int    `default:"8080"`
+	ApiUrl string `default:"https://${Host}:${Port}/api"`
+	User   string `default:"${APP_USER:-anonymous}"`
+	Home   string `default:"/home/${APP_USER}"`
+	Raw    string `default:"$${NotInterpolated}"`
+}
+
+func TestInterpolation(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("Host: example.com\n")},
+	}
+
+	cfg := &InterpolationConfig{}
+	err := ReadConfig(cfg, WithFS(fileSystem), WithInterpolation(), WithEnv(map[string]string{"APP_USER": "gofig", "Port": "9090"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.ApiUrl != "https://example.com:9090/api" {
+		t.Errorf("expected ApiUrl=https://example.com:9090/api, got %s", cfg.ApiUrl)
+	}
+	if cfg.User != "gofig" {
+		t.Errorf("expected User=gofig, got %s", cfg.User)
+	}
+	if cfg.Home != "/home/gofig" {
+		t.Errorf("expected Home=/home/gofig, got %s", cfg.Home)
+	}
+	if cfg.Raw != "${NotInterpolated}" {
+		t.Errorf("expected Raw=${NotInterpolated}, got %s", cfg.Raw)
+	}
+
+	cfg = &InterpolationConfig{}
+	err = ReadConfig(cfg, WithFS(fstest.MapFS{}), WithInterpolation(), WithEnv(map[string]string{"APP_USER": ""}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.User != "anonymous" {
+		t.Errorf("expected User=anonymous, got %s", cfg.User)
+	}
+
+	// without interpolation, values are kept as they are
+	cfg = &InterpolationConfig{}
+	if err := ReadConfig(cfg, WithFS(fstest.MapFS{}), WithEnv(map[string]string{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.ApiUrl != "https://${Host}:${Port}/api" {
+		t.Errorf("expected ApiUrl to not be interpolated, got %s", cfg.ApiUrl)
+	}
+}
+
+func TestInterpolationErrors(t *testing.T) {
+	t.Parallel()
+
+	cfg := &InterpolationConfig{}
+	err := ReadConfig(cfg, WithFS(fstest.MapFS{}), WithInterpolation(), WithEnv(map[string]string{}))
+	if err == nil || !strings.Contains(err.Error(), "undefined reference ${APP_USER}") {
+		t.Errorf("expected undefined reference error, got %v", err)
+	}
+
+	type CyclicConfig struct {
+		First  string `default:"${Second}"`
+		Second string `default:"x${First}"`
+	}
+	err = ReadConfig(&CyclicConfig{}, WithFS(fstest.MapFS{}), WithInterpolation(), WithEnv(map[string]string{}))
+	if err == nil || !strings.Contains(err.Error(), "cyclic reference") {
+		t.Errorf("expected cyclic reference error, got %v", err)
+	}
+
+	type UnterminatedConfig struct {
+		Value string `default:"${Value"`
+	}
+	err = ReadConfig(&UnterminatedConfig{}, WithFS(fstest.MapFS{}), WithInterpolation(), WithEnv(map[string]string{}))
+	if err == nil || !strings.Contains(err.Error(), "unterminated reference") {
+		t.Errorf("expected unterminated reference error, got %v", err)
+	}
+}
+
+func TestInterpolationMasksSecrets(t *testing.T) {
+	t.Parallel()
+
+	type SecretConfig struct {
+		Password string `mask:"true"`
+		Token    string
+		Key      string
+		DSN      string `default:"postgres://app:${Password}@db/app"`
+		Header   string `default:"Bearer ${Token}"`
+		Signing  string `default:"hmac:${Key}"`
+		Combined string `default:"${DSN}"`
+		Public   string `default:"https://${Host}"`
+		Host     string `default:"example.com"`
+	}
+
+	key := []byte("0123456789abcdef")
+	encryptedKey, err := EncryptValue(key, "signingKey")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	report := &ReadReport{}
+	cfg := &SecretConfig{}
+	err = ReadConfig(cfg, WithFS(fstest.MapFS{}), WithInterpolation(), WithReadReport(report), WithDecryptionKey(key), WithResolver("vault", func(reference string) (string, error) {
+		return "vaultToken", nil
+	}), WithEnv(map[string]string{"Password": "topSecret", "Token": "vault://token", "Key": encryptedKey}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.DSN != "postgres://app:topSecret@db/app" {
+		t.Errorf("expected DSN to be interpolated, got %s", cfg.DSN)
+	}
+
+	// referenced keys are resolved and decrypted before being substituted
+	if cfg.Header != "Bearer vaultToken" {
+		t.Errorf("expected Header=Bearer vaultToken, got %s", cfg.Header)
+	}
+	if cfg.Signing != "hmac:signingKey" {
+		t.Errorf("expected Signing=hmac:signingKey, got %s", cfg.Signing)
+	}
+
+	for _, fieldName := range []string{"DSN", "Header", "Signing", "Combined"} {
+		if !report.InterpolatedMaskedFields[fieldName] {
+			t.Errorf("expected %s to be masked, got %v", fieldName, report.InterpolatedMaskedFields)
+		}
+	}
+	if report.InterpolatedMaskedFields["Public"] {
+		t.Errorf("expected Public to not be masked, got %v", report.InterpolatedMaskedFields)
+	}
+
+	var sb strings.Builder
+	LogConfig(cfg, &sb, WithLogReport(report))
+	if strings.Contains(sb.String(), "topSecret") || strings.Contains(sb.String(), "vaultToken") || strings.Contains(sb.String(), "signingKey") {
+		t.Errorf("expected interpolated secret to be masked, got: %s", sb.String())
+	}
+	if !strings.Contains(sb.String(), "https://example.com") {
+		t.Errorf("expected Public to be logged, got: %s", sb.String())
+	}
+}
@@ -1,8 +1,13 @@
 package appgofig
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"maps"
 	"os"
+	"path"
 	"path/filepath"
 	"reflect"
 	"strconv"
@@ -12,101 +17,562 @@ import (
 	"go.yaml.in/yaml/v4"
 )
 
-// applyDefaultsToConfig uses applyStringMapToConfig to apply the default string inputs to targetConfig
-func applyDefaultsToConfig(targetConfig any) error {
-	// read defaults from the type into a map
+// configLayer holds the raw string values of one source and where each of them came from, keyed by field name
+type configLayer struct {
+	values  map[string]string
+	sources map[string]FieldSource
+}
+
+// newConfigLayer returns an empty configLayer
+func newConfigLayer() configLayer {
+	return configLayer{
+		values:  make(map[string]string),
+		sources: make(map[string]FieldSource),
+	}
+}
+
+// set stores value and its source for key
+func (layer configLayer) set(key string, value string, source FieldSource) {
+	layer.values[key] = value
+	layer.sources[key] = source
+}
+
+// newConfigLayerFromMap returns a configLayer containing all values of valueMap with the same source
+func newConfigLayerFromMap(valueMap map[string]string, source FieldSource) configLayer {
+	layer := newConfigLayer()
+	for key, value := range valueMap {
+		layer.set(key, value, source)
+	}
+
+	return layer
+}
+
+// loadConfigLayers reads all sources into layers, ordered by ascending precedence according to the read mode
+func loadConfigLayers(targetConfig any, gofigOptions *AppGofigOptions) ([]configLayer, error) {
+	layers := []configLayer{}
+
+	// default values come first
+	if gofigOptions.NewDefaults == nil {
+		layers = append(layers, readDefaults(targetConfig))
+	} else {
+		layers = append(layers, newConfigLayerFromMap(gofigOptions.NewDefaults, FieldSource{Source: "new-defaults"}))
+	}
+
+	readEnvLayer := func() error {
+		envLayers, err := readEnvironment(targetConfig, gofigOptions)
+		if err != nil {
+			return fmt.Errorf("could not read config values from env: %w", err)
+		}
+
+		layers = append(layers, envLayers...)
+		return nil
+	}
+
+	readYamlLayer := func() error {
+		yamlLayers, err := readYaml(gofigOptions)
+		if err != nil {
+			return fmt.Errorf("could not read config values from yaml: %w", err)
+		}
+
+		layers = append(layers, yamlLayers...)
+
+		// remote config is layered on top of the yaml files
+		if gofigOptions.HTTPSourceRequested {
+			httpLayer, err := readHTTPSource(gofigOptions)
+			if err != nil {
+				return fmt.Errorf("could not read config values from http source: %w", err)
+			}
+
+			layers = append(layers, httpLayer)
+		}
+
+		return nil
+	}
+
+	// read the config according to the read mode
+	var readOrder []func() error
+	switch gofigOptions.ReadMode {
+	case ReadModeEnvOnly:
+		// Only read from environment
+		readOrder = []func() error{readEnvLayer}
+	case ReadModeYamlOnly:
+		// Only read from yaml file
+		readOrder = []func() error{readYamlLayer}
+	case ReadModeEnvThenYaml:
+		// first read from environment, then overwrite existing stuff with yaml
+		readOrder = []func() error{readEnvLayer, readYamlLayer}
+	case ReadModeYamlThenEnv:
+		// first read from yaml, then overwrite existing stuff from environment
+		readOrder = []func() error{readYamlLayer, readEnvLayer}
+	default:
+		return nil, fmt.Errorf("invalid read mode %s", gofigOptions.ReadMode)
+	}
+
+	for _, readLayer := range readOrder {
+		if err := readLayer(); err != nil {
+			return nil, err
+		}
+	}
+
+	// mounted directories are applied on top of all other sources
+	if gofigOptions.MountedDirsRequested {
+		mountedLayer, err := readMountedDirs(targetConfig, gofigOptions)
+		if err != nil {
+			return nil, fmt.Errorf("could not read config values from mounted directories: %w", err)
+		}
+
+		layers = append(layers, mountedLayer)
+	}
+
+	return layers, nil
+}
+
+// mergeConfigLayers merges all layers into a single map and returns it together with the source of each value,
+// where later layers overwrite earlier ones
+func mergeConfigLayers(layers []configLayer) (map[string]string, map[string]FieldSource) {
+	mergedValues := make(map[string]string)
+	mergedSources := make(map[string]FieldSource)
+	for _, layer := range layers {
+		maps.Copy(mergedValues, layer.values)
+		maps.Copy(mergedSources, layer.sources)
+	}
+
+	return mergedValues, mergedSources
+}
+
+// readDefaults reads the default tags of targetConfig into a layer
+func readDefaults(targetConfig any) configLayer {
 	t := reflect.TypeOf(targetConfig).Elem()
-	defaultsMap := make(map[string]string)
+	defaultsLayer := newConfigLayer()
 
 	for k := 0; k < t.NumField(); k++ {
 		field := t.Field(k)
-		defaultsMap[field.Name] = strings.TrimSpace(field.Tag.Get("default"))
+		defaultsLayer.set(field.Name, strings.TrimSpace(field.Tag.Get("default")), FieldSource{Source: "default"})
 	}
 
-	if err := applyStringMapToConfig(targetConfig, defaultsMap); err != nil {
-		return fmt.Errorf("unable to apply default values: %w", err)
-	}
-
-	return nil
+	return defaultsLayer
 }
 
-// applyEnvironmentToConfig applies environment values to targetConfig while loading .env files first
-func applyEnvironmentToConfig(targetConfig any) error {
-	// load .env
-	// error is ignored on purpose, as not having .env is not an issue
-	godotenv.Load()
+// readEnvironment reads environment values for all fields of targetConfig, using values of dotenv files
+// for keys that are not present in the environment. The process environment itself is never modified.
+// Keys are resolved using gofigOptions.EnvLookup, which defaults to os.LookupEnv.
+// If <KEY>_FILE is present instead of the key, the content of the referenced file is used as value.
+// Returns one layer per dotenv file and reader followed by the environment layer, so every candidate is kept
+func readEnvironment(targetConfig any, gofigOptions *AppGofigOptions) ([]configLayer, error) {
+	dotenvLayers, err := readDotenvFiles(gofigOptions)
+	if err != nil {
+		return nil, err
+	}
 
-	// gather environment map
-	envMap := make(map[string]string)
+	// although the envKey is used to lookup the value,
+	// the layers need the actual field.Name here as that is used to
+	// map it to the field name in the actual config struct
+	fieldLayers := make([]configLayer, len(dotenvLayers))
+	for i := range fieldLayers {
+		fieldLayers[i] = newConfigLayer()
+	}
+	fieldLayers = append(fieldLayers, newConfigLayer())
 
 	t := reflect.TypeOf(targetConfig).Elem()
 	for k := 0; k < t.NumField(); k++ {
 		field := t.Field(k)
-		fieldEnv, hasEnv := field.Tag.Lookup("env")
+		keyToUse := fieldEnvKey(field)
 
-		keyToUse := field.Name
-		if hasEnv {
-			keyToUse = fieldEnv
+		for i, layer := range dotenvLayers {
+			if dotenvVal, ok := layer.values[keyToUse]; ok {
+				fieldLayers[i].set(field.Name, strings.TrimSpace(dotenvVal), layer.sources[keyToUse])
+			}
 		}
 
-		envVal, hasEnvVal := os.LookupEnv(keyToUse)
+		// secrets can be provided as file path within <KEY>_FILE instead. The layer with the highest precedence
+		// setting either of them wins, so the environment always beats the dotenv files
+		fileKey := keyToUse + fileEnvSuffix
+		for layerIndex := len(dotenvLayers); layerIndex >= 0; layerIndex-- {
+			envVal, envSource, hasEnvVal := lookupEnvLayer(gofigOptions, dotenvLayers, layerIndex, keyToUse)
+			filePath, fileSource, hasFilePath := lookupEnvLayer(gofigOptions, dotenvLayers, layerIndex, fileKey)
+
+			if hasEnvVal && hasFilePath {
+				return nil, fmt.Errorf("only one of %s and %s can be set", keyToUse, fileKey)
+			}
+
+			if hasFilePath {
+				data, err := readSecretFile(strings.TrimSpace(filePath))
+				if err != nil {
+					return nil, fmt.Errorf("unable to read file of %s (%q): %w", fileKey, filePath, err)
+				}
 
-		// although the envKey is used to lookup the value,
-		// the envMap needs the actual field.Name here as that is used to
-		// map it to the field name in the actual config struct
-		if hasEnvVal {
-			envMap[field.Name] = strings.TrimSpace(envVal)
+				envVal = strings.TrimRight(string(data), "\r\n")
+				envSource = fileSource
+				hasEnvVal = true
+			}
+
+			if hasEnvVal {
+				fieldLayers[layerIndex].set(field.Name, strings.TrimSpace(envVal), envSource)
+				break
+			}
 		}
 	}
 
-	if err := applyStringMapToConfig(targetConfig, envMap); err != nil {
-		return err
+	return fieldLayers, nil
+}
+
+// lookupEnvLayer looks up key within the dotenv layer at layerIndex, where len(dotenvLayers) is the environment itself
+func lookupEnvLayer(gofigOptions *AppGofigOptions, dotenvLayers []configLayer, layerIndex int, key string) (string, FieldSource, bool) {
+	if layerIndex == len(dotenvLayers) {
+		envVal, hasEnvVal := gofigOptions.EnvLookup(key)
+		return envVal, FieldSource{Source: "env", Location: key}, hasEnvVal
 	}
 
-	return nil
+	envVal, hasEnvVal := dotenvLayers[layerIndex].values[key]
+	return envVal, dotenvLayers[layerIndex].sources[key], hasEnvVal
 }
 
-// applyYamlToConfig checks for (config/)config.y(a)ml files and applies the first one found to targetConfig
-func applyYamlToConfig(targetConfig any, gofigOptions *AppGofigOptions) error {
-	yamlFilePath := ""
-	if gofigOptions.YamlFileRequested {
-		yamlFilePath = gofigOptions.YamlFilePath
-	} else {
-		// check for a config.yml or config.yaml in root directory or within a config folder
-		// any value here overwrites the rest
-		defaultYamlPaths := []string{"config.yml", "config.yaml", "config/config.yml", "config/config.yaml"}
-		for _, path := range defaultYamlPaths {
-			if _, err := os.Stat(path); err == nil {
-				yamlFilePath = path
-				break
+// readDotenvFiles reads the dotenv files and readers according to gofigOptions into one layer each, keyed by env key.
+// Later layers overwrite values of earlier ones
+func readDotenvFiles(gofigOptions *AppGofigOptions) ([]configLayer, error) {
+	dotenvLayers := []configLayer{}
+
+	if gofigOptions.DotenvDisabled {
+		return dotenvLayers, nil
+	}
+
+	if !gofigOptions.DotenvFilesRequested {
+		// read .env
+		// error is ignored on purpose, as not having .env is not an issue
+		if data, err := readConfigFile(gofigOptions, ".env"); err == nil {
+			if fileValues, err := godotenv.UnmarshalBytes(data); err == nil {
+				dotenvLayers = append(dotenvLayers, newConfigLayerFromMap(fileValues, FieldSource{Source: "dotenv", Location: ".env"}))
 			}
 		}
 	}
 
-	if len(yamlFilePath) == 0 {
-		return nil
+	for _, path := range gofigOptions.DotenvFiles {
+		data, err := readConfigFile(gofigOptions, path)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("unable to read dotenv file (%q): %w", path, err)
+		}
+
+		fileValues, err := godotenv.UnmarshalBytes(data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse dotenv file (%q): %w", path, err)
+		}
+
+		dotenvLayers = append(dotenvLayers, newConfigLayerFromMap(fileValues, FieldSource{Source: "dotenv", Location: path}))
+	}
+
+	// readers are layered on top of the dotenv files
+	for _, reader := range gofigOptions.DotenvReaders {
+		readerValues, err := godotenv.Parse(reader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse dotenv reader: %w", err)
+		}
+
+		dotenvLayers = append(dotenvLayers, newConfigLayerFromMap(readerValues, FieldSource{Source: "dotenv", Location: "reader"}))
+	}
+
+	return dotenvLayers, nil
+}
+
+// readYaml reads all yaml files and readers (base files each followed by its profile file, readers and drop-in fragments)
+// according to gofigOptions into one layer each, in the order they are applied
+func readYaml(gofigOptions *AppGofigOptions) ([]configLayer, error) {
+	yamlLayers := []configLayer{}
+
+	for _, yamlFile := range resolveYamlFiles(gofigOptions) {
+		fileLayer, err := readYamlFile(gofigOptions, yamlFile.Path)
+		if errors.Is(err, fs.ErrNotExist) && yamlFile.Optional {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		yamlLayers = append(yamlLayers, reportYamlLayer(gofigOptions, fileLayer, yamlFile.Path))
+
+		// the profile file is layered directly on top of its base file, so later files still overwrite it
+		if gofigOptions.ProfileRequested {
+			profilePath := profileYamlPath(yamlFile.Path, gofigOptions.Profile)
+			profileLayer, err := readYamlFile(gofigOptions, profilePath)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			yamlLayers = append(yamlLayers, reportYamlLayer(gofigOptions, profileLayer, profilePath))
+		}
 	}
 
-	data, err := os.ReadFile(filepath.Clean(yamlFilePath))
+	// readers (e.g. stdin) are layered on top of the files
+	for _, reader := range gofigOptions.YamlReaders {
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read yaml reader: %w", err)
+		}
+
+		readerLayer, err := parseConfigData(data, "yaml", "reader")
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse yaml reader: %w", err)
+		}
+
+		yamlLayers = append(yamlLayers, reportYamlLayer(gofigOptions, readerLayer, "reader"))
+	}
+
+	// drop-in fragments are layered on top of everything else
+	if gofigOptions.DropInDirRequested {
+		fragmentPaths, err := readDropInDir(gofigOptions)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, fragmentPath := range fragmentPaths {
+			fileLayer, err := readYamlFile(gofigOptions, fragmentPath)
+			if err != nil {
+				return nil, err
+			}
+
+			yamlLayers = append(yamlLayers, reportYamlLayer(gofigOptions, fileLayer, fragmentPath))
+		}
+	}
+
+	if gofigOptions.YamlFileRequired && len(gofigOptions.Report.YamlFiles) == 0 {
+		if len(gofigOptions.Report.SearchedPaths) > 0 {
+			return nil, fmt.Errorf("no yaml file found, searched %s", strings.Join(gofigOptions.Report.SearchedPaths, ", "))
+		}
+		return nil, fmt.Errorf("no yaml file found")
+	}
+
+	return yamlLayers, nil
+}
+
+// reportYamlLayer reports yamlFilePath as loaded file and as source of each key of fileLayer, which is returned as is
+func reportYamlLayer(gofigOptions *AppGofigOptions, fileLayer configLayer, yamlFilePath string) configLayer {
+	gofigOptions.Report.YamlFiles = append(gofigOptions.Report.YamlFiles, yamlFilePath)
+
+	for key := range fileLayer.values {
+		gofigOptions.Report.YamlSources[key] = yamlFilePath
+	}
+
+	return fileLayer
+}
+
+// readDropInDir returns the paths of all yaml files within the drop-in directory in lexical order.
+// A missing directory is not an error
+func readDropInDir(gofigOptions *AppGofigOptions) ([]string, error) {
+	var entries []fs.DirEntry
+	var err error
+	if gofigOptions.FS == nil {
+		entries, err = os.ReadDir(filepath.Clean(gofigOptions.DropInDir))
+	} else {
+		entries, err = fs.ReadDir(gofigOptions.FS, fsPath(gofigOptions.DropInDir))
+	}
+
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
 	if err != nil {
-		return err
+		return nil, fmt.Errorf("unable to read drop-in directory (%q): %w", gofigOptions.DropInDir, err)
+	}
+
+	// entries are already sorted by filename
+	fragmentPaths := []string{}
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yml" && ext != ".yaml") {
+			continue
+		}
+
+		fragmentPaths = append(fragmentPaths, filepath.Join(gofigOptions.DropInDir, entry.Name()))
+	}
+
+	return fragmentPaths, nil
+}
+
+// resolveYamlFiles returns the yaml files to read in order. If none were specified, the file referenced by the
+// config path env var or the first existing one of the search paths (default: (config/)config.y(a)ml) is used
+func resolveYamlFiles(gofigOptions *AppGofigOptions) []YamlFile {
+	if gofigOptions.YamlFileRequested {
+		return []YamlFile{{Path: gofigOptions.YamlFilePath, Optional: false}}
+	}
+
+	if gofigOptions.YamlFilesRequested {
+		return gofigOptions.YamlFiles
 	}
 
-	yamlMap := make(map[string]string)
+	// readers replace the discovery of yaml files
+	if len(gofigOptions.YamlReaders) > 0 {
+		return nil
+	}
 
-	if err := yaml.Unmarshal(data, &yamlMap); err != nil {
-		return err
+	// a config file referenced by env is required to exist
+	if gofigOptions.ConfigPathEnvRequested {
+		if configPath, ok := gofigOptions.EnvLookup(gofigOptions.ConfigPathEnv); ok && len(strings.TrimSpace(configPath)) > 0 {
+			gofigOptions.Report.ConfigFile = strings.TrimSpace(configPath)
+			return []YamlFile{{Path: gofigOptions.Report.ConfigFile, Optional: false}}
+		}
 	}
 
-	if err := applyStringMapToConfig(targetConfig, yamlMap); err != nil {
-		return err
+	// check every search directory for the config names, first hit wins
+	for _, dir := range gofigOptions.SearchDirs {
+		// helpers like ExecutableDir return an empty string if the directory cannot be determined
+		if len(dir) == 0 {
+			continue
+		}
+
+		for _, name := range gofigOptions.ConfigNames {
+			path := filepath.Join(dir, name)
+			gofigOptions.Report.SearchedPaths = append(gofigOptions.Report.SearchedPaths, path)
+
+			if _, err := statConfigFile(gofigOptions, path); err == nil {
+				gofigOptions.Report.ConfigFile = path
+				return []YamlFile{{Path: path, Optional: false}}
+			}
+		}
 	}
 
 	return nil
 }
 
+// readYamlFile reads a flat yaml file into a layer
+func readYamlFile(gofigOptions *AppGofigOptions, yamlFilePath string) (configLayer, error) {
+	data, err := readConfigFile(gofigOptions, yamlFilePath)
+	if err != nil {
+		return configLayer{}, fmt.Errorf("unable to read yaml file (%q): %w", yamlFilePath, err)
+	}
+
+	fileLayer, err := parseConfigData(data, "yaml", yamlFilePath)
+	if err != nil {
+		return configLayer{}, fmt.Errorf("unable to parse yaml file (%q): %w", yamlFilePath, err)
+	}
+
+	return fileLayer, nil
+}
+
+// parseConfigData parses flat yaml (or json, which is valid yaml) into a layer, using sourceKind, location and the line
+// of each key as source
+func parseConfigData(data []byte, sourceKind string, location string) (configLayer, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return configLayer{}, err
+	}
+
+	parsedLayer := newConfigLayer()
+
+	// empty documents contain no content at all
+	if len(document.Content) == 0 {
+		return parsedLayer, nil
+	}
+
+	configMap := make(map[string]string)
+	if err := document.Decode(&configMap); err != nil {
+		return parsedLayer, err
+	}
+
+	// mapping nodes contain key and value nodes alternately
+	lines := make(map[string]int)
+	if root := document.Content[0]; root.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(root.Content); i += 2 {
+			lines[root.Content[i].Value] = root.Content[i].Line
+		}
+	}
+
+	for key, value := range configMap {
+		parsedLayer.set(key, value, FieldSource{Source: sourceKind, Location: location, Line: lines[key]})
+	}
+
+	return parsedLayer, nil
+}
+
+// profileYamlPath returns the path of the profile specific variant of yamlFilePath,
+// e.g. config/config.production.yaml for config/config.yaml
+func profileYamlPath(yamlFilePath string, profile string) string {
+	ext := filepath.Ext(yamlFilePath)
+	return strings.TrimSuffix(yamlFilePath, ext) + "." + profile + ext
+}
+
+// readMountedDirs reads values of mounted directories (e.g. Kubernetes ConfigMaps or Secrets) for all fields of targetConfig into a layer.
+// Every file is named after the field name or the env key of a field and contains its value.
+// As only these names are looked up, the ..data symlink machinery of Kubernetes is ignored
+func readMountedDirs(targetConfig any, gofigOptions *AppGofigOptions) (configLayer, error) {
+	mountedLayer := newConfigLayer()
+
+	t := reflect.TypeOf(targetConfig).Elem()
+	for _, dirPath := range gofigOptions.MountedDirs {
+		// missing directories are skipped, e.g. for optional volumes. Like other secret files,
+		// mounted files are always read from disk, as WithFS only covers the config files
+		if _, err := os.Stat(filepath.Clean(dirPath)); errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+
+		for k := 0; k < t.NumField(); k++ {
+			field := t.Field(k)
+
+			fileNames := []string{field.Name}
+			if fieldEnv := fieldEnvKey(field); fieldEnv != field.Name && len(fieldEnv) > 0 {
+				fileNames = append(fileNames, fieldEnv)
+			}
+
+			for _, fileName := range fileNames {
+				filePath := filepath.Join(dirPath, fileName)
+				data, err := readSecretFile(filePath)
+				if errors.Is(err, fs.ErrNotExist) {
+					continue
+				}
+				if err != nil {
+					return configLayer{}, fmt.Errorf("unable to read mounted file (%q): %w", filePath, err)
+				}
+
+				mountedLayer.set(field.Name, strings.TrimRight(string(data), "\r\n"), FieldSource{Source: "mounted", Location: filePath})
+				gofigOptions.Report.MountedSources[field.Name] = filePath
+				break
+			}
+		}
+	}
+
+	return mountedLayer, nil
+}
+
+// statConfigFile returns the file info of path, using gofigOptions.FS if set
+func statConfigFile(gofigOptions *AppGofigOptions, filePath string) (fs.FileInfo, error) {
+	if gofigOptions.FS == nil {
+		return os.Stat(filepath.Clean(filePath))
+	}
+
+	return fs.Stat(gofigOptions.FS, fsPath(filePath))
+}
+
+// readConfigFile reads the contents of path, using gofigOptions.FS if set
+func readConfigFile(gofigOptions *AppGofigOptions, filePath string) ([]byte, error) {
+	if gofigOptions.FS == nil {
+		return os.ReadFile(filepath.Clean(filePath))
+	}
+
+	return fs.ReadFile(gofigOptions.FS, fsPath(filePath))
+}
+
+// readSecretFile reads a file referenced at runtime, e.g. by <KEY>_FILE. Such files are always read from the
+// local disk, as WithFS only covers the config files themselves
+func readSecretFile(filePath string) ([]byte, error) {
+	return os.ReadFile(filepath.Clean(filePath))
+}
+
+// fsPath converts filePath to a path valid for fs.FS, treating absolute paths as relative to the root of the FS
+func fsPath(filePath string) string {
+	cleanPath := strings.TrimPrefix(path.Clean(filepath.ToSlash(filePath)), "/")
+	if len(cleanPath) == 0 {
+		return "."
+	}
+
+	return cleanPath
+}
+
 // applyStringMapToConfig sets values on targetConfig based on a string map where fieldName == stringMapKey. Non-existing keys are ignored.
-func applyStringMapToConfig(targetConfig any, stringValueMap map[string]string) error {
+// Errors of fields masked according to report do not contain their value
+func applyStringMapToConfig(targetConfig any, stringValueMap map[string]string, report *ReadReport) error {
 	// iterate over targetConfig while applying the string values converted to the actual target type
 
 	v := reflect.ValueOf(targetConfig).Elem()
@@ -121,6 +587,11 @@ func applyStringMapToConfig(targetConfig any, stringValueMap map[string]string)
 			continue
 		} else {
 			if err := applyStringToValue(field, fieldVal, strings.TrimSpace(stringInput)); err != nil {
+				// the error of applyStringToValue contains the value as well
+				if isMaskedField(report, field) {
+					return fmt.Errorf("unable to write masked value to field %s : not a valid %s", field.Name, field.Type.Kind())
+				}
+
 				return fmt.Errorf("unable to write value %s to field %s : %w", stringInput, field.Name, err)
 			}
 		}
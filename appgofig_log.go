@@ -0,0 +1,234 @@
+package appgofig
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// LogFormat is the output format of LogConfig
+type LogFormat string
+
+const (
+	// LogFormatText is the human readable "#| key : value" format
+	LogFormatText LogFormat = "text"
+	// LogFormatJSON writes a single line JSON object with one key per field
+	LogFormatJSON LogFormat = "json"
+	// LogFormatLogfmt writes a single line of key=value pairs
+	LogFormatLogfmt LogFormat = "logfmt"
+)
+
+type LogOptions struct {
+	Format  LogFormat
+	Reports []*ReadReport
+}
+
+type LogOption func(*LogOptions)
+
+// WithLogFormat sets the output format of LogConfig
+func WithLogFormat(format LogFormat) LogOption {
+	return func(options *LogOptions) {
+		options.Format = format
+	}
+}
+
+// WithLogReport uses report (see WithReadReport) to mask resolved and decrypted values and to show the source of every field.
+// It can be passed multiple times, e.g. with the reports of both configs compared by Diff
+func WithLogReport(report *ReadReport) LogOption {
+	return func(options *LogOptions) {
+		if report != nil {
+			options.Reports = append(options.Reports, report)
+		}
+	}
+}
+
+// newLogOptions applies logOptionList to the default log options
+func newLogOptions(logOptionList []LogOption) *LogOptions {
+	logOptions := &LogOptions{
+		Format:  LogFormatText,
+		Reports: nil,
+	}
+
+	for _, opt := range logOptionList {
+		opt(logOptions)
+	}
+
+	return logOptions
+}
+
+// isMaskedField returns true if the field has a "mask" tag or is masked according to one of the reports
+func (options *LogOptions) isMaskedField(field reflect.StructField) bool {
+	for _, report := range options.Reports {
+		if isMaskedField(report, field) {
+			return true
+		}
+	}
+
+	return shouldBeMasked(field)
+}
+
+// fieldSource returns the source of the field according to the first report knowing it
+func (options *LogOptions) fieldSource(fieldName string) (FieldSource, bool) {
+	for _, report := range options.Reports {
+		if fieldSource, ok := report.Fields[fieldName]; ok {
+			return fieldSource, true
+		}
+	}
+
+	return FieldSource{}, false
+}
+
+// logEntry is a single field prepared for logging, with masking already applied
+type logEntry struct {
+	key string
+	// value is the typed value (string, int, float64 or bool), or the masked string for masked fields
+	value     any
+	stringVal string
+	source    FieldSource
+	hasSource bool
+}
+
+// readLogEntries returns one entry per field of targetConfig, masking fields according to their tags and the reports of logOptions
+func readLogEntries(targetConfig any, logOptions *LogOptions) []logEntry {
+	v := reflect.ValueOf(targetConfig).Elem()
+	t := v.Type()
+
+	entries := []logEntry{}
+	for k := 0; k < t.NumField(); k++ {
+		field := t.Field(k)
+		val := v.Field(k)
+
+		entry := logEntry{
+			key:       field.Name,
+			value:     readTypedValue(val),
+			stringVal: readStringFromValue(val),
+		}
+
+		if logOptions.isMaskedField(field) {
+			entry.stringVal = maskValue(field, entry.stringVal)
+			entry.value = entry.stringVal
+		}
+
+		entry.source, entry.hasSource = logOptions.fieldSource(field.Name)
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// readTypedValue returns the value of supported kinds as string, int, float64 or bool. Unlike val.Interface(),
+// this also works for unexported fields. Other kinds are returned as their string representation
+func readTypedValue(val reflect.Value) any {
+	switch val.Kind() {
+	case reflect.String:
+		return val.String()
+	case reflect.Int:
+		return int(val.Int())
+	case reflect.Float64:
+		return val.Float()
+	case reflect.Bool:
+		return val.Bool()
+	default:
+		return readStringFromValue(val)
+	}
+}
+
+// writeTextLog writes the entries in the "#| key : value" format, adding the source as extra column if known
+func writeTextLog(out io.Writer, entries []logEntry) {
+	fmt.Fprint(out, "### AppGofig Configuration Start ###\n")
+
+	for _, entry := range entries {
+		if entry.hasSource {
+			fmt.Fprintf(out, "#| %s : %s | %s\n", entry.key, entry.stringVal, entry.source)
+			continue
+		}
+
+		fmt.Fprintf(out, "#| %s : %s\n", entry.key, entry.stringVal)
+	}
+
+	fmt.Fprint(out, "### AppGofig Configuration End ###\n")
+}
+
+// writeJSONLog writes the entries as a single line JSON object, keeping the order of the fields
+func writeJSONLog(out io.Writer, entries []logEntry) {
+	var sb strings.Builder
+
+	sb.WriteString("{")
+	for i, entry := range entries {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+
+		key, _ := json.Marshal(entry.key)
+		value, err := json.Marshal(entry.value)
+		if err != nil {
+			// e.g. NaN floats, which JSON cannot represent
+			value, _ = json.Marshal(entry.stringVal)
+		}
+
+		sb.Write(key)
+		sb.WriteString(":")
+		sb.Write(value)
+	}
+	sb.WriteString("}\n")
+
+	fmt.Fprint(out, sb.String())
+}
+
+// writeLogfmtLog writes the entries as a single line of key=value pairs, quoting values where necessary
+func writeLogfmtLog(out io.Writer, entries []logEntry) {
+	pairs := []string{}
+	for _, entry := range entries {
+		value := entry.stringVal
+		if len(value) == 0 || strings.ContainsAny(value, " =\"\\\t\n") {
+			value = strconv.Quote(value)
+		}
+
+		pairs = append(pairs, entry.key+"="+value)
+	}
+
+	fmt.Fprint(out, strings.Join(pairs, " ")+"\n")
+}
+
+// LogConfigToSlog logs the configuration as a single info record with one attribute per field, masked like LogConfig
+func LogConfigToSlog(targetConfig any, logger *slog.Logger, logOptionList ...LogOption) {
+	entries := readLogEntries(targetConfig, newLogOptions(logOptionList))
+	logger.LogAttrs(context.Background(), slog.LevelInfo, "AppGofig Configuration", logAttrs(entries)...)
+}
+
+// logAttrs converts the entries into slog attributes
+func logAttrs(entries []logEntry) []slog.Attr {
+	attrs := []slog.Attr{}
+	for _, entry := range entries {
+		attrs = append(attrs, slog.Any(entry.key, entry.value))
+	}
+
+	return attrs
+}
+
+// loggableConfig wraps a config struct for slog, see Loggable
+type loggableConfig struct {
+	targetConfig any
+	logOptions   *LogOptions
+}
+
+// Loggable wraps targetConfig into a slog.LogValuer, which logs the config as group with one attribute per field,
+// masked like LogConfig
+func Loggable(targetConfig any, logOptionList ...LogOption) slog.LogValuer {
+	return loggableConfig{targetConfig: targetConfig, logOptions: newLogOptions(logOptionList)}
+}
+
+// LogValue implements slog.LogValuer
+func (loggable loggableConfig) LogValue() slog.Value {
+	if v := reflect.ValueOf(loggable.targetConfig); v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return slog.StringValue(fmt.Sprintf("!appgofig: %T is not a pointer to a struct", loggable.targetConfig))
+	}
+
+	return slog.GroupValue(logAttrs(readLogEntries(loggable.targetConfig, loggable.logOptions))...)
+}
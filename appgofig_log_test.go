@@ -0,0 +1,182 @@
+package appgofig
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newLogTestConfig() *TestConfig {
+	return &TestConfig{StringVal: "hello world", IntVal: 42, BoolVal: true, SecretVal: "topsecret", FloatVal: 0.5}
+}
+
+func TestLogConfigFormats(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		options  []LogOption
+		expected string
+	}{
+		{
+			name:    "text",
+			options: nil,
+			expected: "### AppGofig Configuration Start ###\n" +
+				"#| StringVal : hello world\n" +
+				"#| IntVal : 42\n" +
+				"#| BoolVal : true\n" +
+				"#| SecretVal : [Masked]\n" +
+				"#| FloatVal : 0.5\n" +
+				"### AppGofig Configuration End ###\n",
+		},
+		{
+			name:     "json",
+			options:  []LogOption{WithLogFormat(LogFormatJSON)},
+			expected: `{"StringVal":"hello world","IntVal":42,"BoolVal":true,"SecretVal":"[Masked]","FloatVal":0.5}` + "\n",
+		},
+		{
+			name:     "logfmt",
+			options:  []LogOption{WithLogFormat(LogFormatLogfmt)},
+			expected: `StringVal="hello world" IntVal=42 BoolVal=true SecretVal=[Masked] FloatVal=0.5` + "\n",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var out bytes.Buffer
+			LogConfig(newLogTestConfig(), &out, test.options...)
+
+			if out.String() != test.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", test.expected, out.String())
+			}
+		})
+	}
+}
+
+func TestLogConfigJSONIsValid(t *testing.T) {
+	t.Parallel()
+
+	cfg := newLogTestConfig()
+	cfg.StringVal = "quote \" and newline \n"
+
+	var out bytes.Buffer
+	LogConfig(cfg, &out, WithLogFormat(LogFormatJSON))
+
+	var decoded map[string]any
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("expected valid json, got %v: %s", err, out.String())
+	}
+	if decoded["StringVal"] != cfg.StringVal {
+		t.Errorf("expected StringVal to survive the round trip, got %v", decoded["StringVal"])
+	}
+}
+
+func TestLogConfigToSlog(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+
+	LogConfigToSlog(newLogTestConfig(), logger)
+
+	if strings.Count(out.String(), "\n") != 1 {
+		t.Fatalf("expected a single record, got: %s", out.String())
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if record["msg"] != "AppGofig Configuration" || record["IntVal"] != float64(42) || record["BoolVal"] != true {
+		t.Errorf("expected one attribute per field, got %v", record)
+	}
+	if record["SecretVal"] != "[Masked]" {
+		t.Errorf("expected SecretVal to be masked, got %v", record["SecretVal"])
+	}
+}
+
+func TestLoggable(t *testing.T) {
+	t.Parallel()
+
+	var out bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&out, nil))
+
+	logger.Info("config", "cfg", Loggable(newLogTestConfig()))
+
+	var record struct {
+		Cfg map[string]any `json:"cfg"`
+	}
+	if err := json.Unmarshal(out.Bytes(), &record); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if record.Cfg["StringVal"] != "hello world" || record.Cfg["IntVal"] != float64(42) {
+		t.Errorf("expected config to be logged as group, got %v", record.Cfg)
+	}
+	if record.Cfg["SecretVal"] != "[Masked]" {
+		t.Errorf("expected SecretVal to be masked, got %v", record.Cfg["SecretVal"])
+	}
+}
+
+func TestLoggableUnsupportedFields(t *testing.T) {
+	t.Parallel()
+
+	type databaseConfig struct {
+		host     string
+		password string
+	}
+	type unsupportedConfig struct {
+		Name      string
+		StartedAt time.Time
+		Database  databaseConfig
+	}
+
+	var out bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&out, nil))
+
+	cfg := &unsupportedConfig{Name: "app", StartedAt: time.Now(), Database: databaseConfig{host: "db", password: "secret"}}
+	logger.Info("config", "cfg", Loggable(cfg))
+
+	// structs are not supported config types, so their fields are not logged
+	logOutput := out.String()
+	if !strings.Contains(logOutput, "cfg.Name=app") || !strings.Contains(logOutput, `cfg.StartedAt=" - unsupported type struct - "`) {
+		t.Errorf("expected unsupported fields to be logged as such, got: %s", logOutput)
+	}
+	if strings.Contains(logOutput, "secret") {
+		t.Errorf("expected nested fields to not be logged, got: %s", logOutput)
+	}
+
+	out.Reset()
+	logger.Info("config", "cfg", Loggable(unsupportedConfig{}))
+	if !strings.Contains(out.String(), "is not a pointer to a struct") {
+		t.Errorf("expected hint for non-pointer, got: %s", out.String())
+	}
+}
+
+func TestLogConfigUnexportedField(t *testing.T) {
+	t.Parallel()
+
+	type unexportedConfig struct {
+		Name  string
+		port  int
+		ratio float64
+	}
+	cfg := &unexportedConfig{Name: "app", port: 8080, ratio: 0.5}
+
+	var sb strings.Builder
+	LogConfig(cfg, &sb, WithLogFormat(LogFormatJSON))
+	if sb.String() != "{\"Name\":\"app\",\"port\":8080,\"ratio\":0.5}\n" {
+		t.Errorf("expected unexported fields to be logged, got: %s", sb.String())
+	}
+
+	var out bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&out, nil))
+	logger.Info("config", "cfg", Loggable(cfg))
+	if !strings.Contains(out.String(), "cfg.port=8080") {
+		t.Errorf("expected unexported field to be logged, got: %s", out.String())
+	}
+}
@@ -0,0 +1,108 @@
+package appgofig
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMaskStrategies(t *testing.T) {
+	t.Parallel()
+
+	type maskConfig struct {
+		Full        string `mask:"true"`
+		FullNamed   string `mask:"full"`
+		Length      string `mask:"length"`
+		First       string `mask:"first:3"`
+		Last        string `mask:"last:4"`
+		FirstTooBig string `mask:"first:10"`
+		Fingerprint string `mask:"sha256"`
+		URL         string `mask:"url"`
+		URLNoPass   string `mask:"url"`
+		NoURL       string `mask:"url"`
+		MySQLDSN    string `mask:"url"`
+		NoHost      string `mask:"url"`
+		AtInQuery   string `mask:"url"`
+		Untagged    string
+	}
+
+	cfg := maskConfig{
+		Full:        "password",
+		FullNamed:   "password",
+		Length:      "password",
+		First:       "sk_live_123456",
+		Last:        "4111111111111111",
+		FirstTooBig: "short",
+		Fingerprint: "password",
+		URL:         "postgres://user:pass@db:5432/app?sslmode=disable",
+		URLNoPass:   "postgres://user@db:5432/app",
+		NoURL:       "pass word",
+		MySQLDSN:    "user:pass@tcp(db:3306)/app",
+		NoHost:      "file:///user:pass@local",
+		AtInQuery:   "https://db/app?login=user:pass@db",
+		Untagged:    "password",
+	}
+
+	expected := map[string]string{
+		"Full":        "[Masked]",
+		"FullNamed":   "[Masked]",
+		"Length":      "[Masked - Length: 8]",
+		"First":       "sk_[Masked]",
+		"Last":        "[Masked]1111",
+		"FirstTooBig": "[Masked]",
+		"Fingerprint": "[Masked - SHA256: 5e884898]",
+		"URL":         "postgres://user:[Masked]@db:5432/app?sslmode=disable",
+		"URLNoPass":   "postgres://user@db:5432/app",
+		"NoURL":       "[Masked]",
+		"MySQLDSN":    "[Masked]",
+		"NoHost":      "[Masked]",
+		"AtInQuery":   "[Masked]",
+		"Untagged":    "[Masked]",
+	}
+
+	v := reflect.ValueOf(cfg)
+	for k := 0; k < v.NumField(); k++ {
+		field := v.Type().Field(k)
+
+		if masked := maskValue(field, v.Field(k).String()); masked != expected[field.Name] {
+			t.Errorf("field %s: expected %q, got %q", field.Name, expected[field.Name], masked)
+		}
+	}
+}
+
+func TestMaskStrategyInLogConfig(t *testing.T) {
+	t.Parallel()
+
+	type maskConfig struct {
+		DatabaseURL string `default:"postgres://user:pass@db/app" mask:"url"`
+		Disabled    string `default:"visible" mask:"false"`
+	}
+
+	cfg := &maskConfig{}
+	if err := ReadConfig(cfg, WithEnv(map[string]string{}), WithoutDotenv(), WithReadMode(ReadModeEnvOnly)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var sb strings.Builder
+	LogConfig(cfg, &sb)
+
+	if !strings.Contains(sb.String(), "#| DatabaseURL : postgres://user:[Masked]@db/app") {
+		t.Errorf("expected only the password to be masked, got: %s", sb.String())
+	}
+	if !strings.Contains(sb.String(), "#| Disabled : visible") {
+		t.Errorf("expected mask:\"false\" to not mask, got: %s", sb.String())
+	}
+}
+
+func TestInvalidMaskTag(t *testing.T) {
+	t.Parallel()
+
+	type invalidMaskConfig struct {
+		Secret string `mask:"first:x"`
+	}
+
+	err := ReadConfig(&invalidMaskConfig{}, WithEnv(map[string]string{}), WithoutDotenv(), WithReadMode(ReadModeEnvOnly))
+	if err == nil || !strings.Contains(err.Error(), "invalid mask tag") {
+		t.Fatalf("expected error for invalid mask tag, got %v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package appgofig
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// ExecutableDir returns the directory of the running executable, or an empty string if it cannot be determined
+func ExecutableDir() string {
+	executable, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Dir(executable)
+}
+
+// UserConfigDir returns the config directory of appName for the current user (e.g. $XDG_CONFIG_HOME/<appName>),
+// or an empty string if it cannot be determined
+func UserConfigDir(appName string) string {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(configDir, appName)
+}
+
+// SystemConfigDir returns the system wide config directory of appName (/etc/<appName>)
+func SystemConfigDir(appName string) string {
+	return filepath.Join("/etc", appName)
+}
@@ -0,0 +1,41 @@
+package appgofig
+
+import (
+	"reflect"
+	"strconv"
+)
+
+// FieldSource describes where the value of a field came from
+type FieldSource struct {
+	// Source is the kind of source: default, new-defaults, env, dotenv, yaml, http, http-cache (the cached config of
+	// an unavailable http source) or mounted
+	Source string
+	// Location is the exact origin within the source, e.g. the env key, a file path or an url. Empty for defaults
+	Location string
+	// Line is the line within a yaml file, 0 if unknown
+	Line int
+}
+
+// String returns a short representation like "yaml config.yaml:3" or "env MY_SETTING"
+func (fieldSource FieldSource) String() string {
+	if len(fieldSource.Location) == 0 {
+		return fieldSource.Source
+	}
+
+	if fieldSource.Line > 0 {
+		return fieldSource.Source + " " + fieldSource.Location + ":" + strconv.Itoa(fieldSource.Line)
+	}
+
+	return fieldSource.Source + " " + fieldSource.Location
+}
+
+// recordFieldSources stores the source of every field of targetConfig within report
+func recordFieldSources(targetConfig any, mergedSources map[string]FieldSource, report *ReadReport) {
+	t := reflect.TypeOf(targetConfig).Elem()
+	for k := 0; k < t.NumField(); k++ {
+		field := t.Field(k)
+		if fieldSource, ok := mergedSources[field.Name]; ok {
+			report.Fields[field.Name] = fieldSource
+		}
+	}
+}
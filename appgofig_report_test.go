@@ -0,0 +1,89 @@
+package appgofig
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestFieldSources(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("# comment\nIntVal: 1\n\nBoolVal: false\n")},
+		".env.local":  {Data: []byte("TEST_FLOAT=2.5\n")},
+	}
+
+	report := &ReadReport{}
+	cfg := &TestConfig{}
+	err := ReadConfig(cfg, WithFS(fileSystem), WithReadMode(ReadModeYamlThenEnv), WithReadReport(report),
+		WithDotenvFiles(".env.local"), WithEnv(map[string]string{"TEST_STRING": "fromEnv"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedSources := map[string]FieldSource{
+		"StringVal": {Source: "env", Location: "TEST_STRING"},
+		"IntVal":    {Source: "yaml", Location: "config.yaml", Line: 2},
+		"BoolVal":   {Source: "yaml", Location: "config.yaml", Line: 4},
+		"SecretVal": {Source: "default"},
+		"FloatVal":  {Source: "dotenv", Location: ".env.local"},
+	}
+	for field, expectedSource := range expectedSources {
+		if report.Fields[field] != expectedSource {
+			t.Errorf("expected source %v for %s, got %v", expectedSource, field, report.Fields[field])
+		}
+	}
+
+	var sb strings.Builder
+	LogConfig(cfg, &sb, WithLogReport(report))
+	logOutput := sb.String()
+
+	if !strings.Contains(logOutput, "#| IntVal : 1 | yaml config.yaml:2\n") {
+		t.Errorf("expected source column for IntVal, got: %s", logOutput)
+	}
+	if !strings.Contains(logOutput, "#| StringVal : fromEnv | env TEST_STRING\n") {
+		t.Errorf("expected source column for StringVal, got: %s", logOutput)
+	}
+
+	report = &ReadReport{}
+	err = ReadConfig(cfg, WithFS(fileSystem), WithReadReport(report), WithNewDefaults(map[string]string{"StringVal": "new"}), WithEnv(map[string]string{}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.Fields["StringVal"].String() != "new-defaults" {
+		t.Errorf("expected StringVal from new-defaults, got %s", report.Fields["StringVal"])
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{\n\"IntVal\": 5000\n}"))
+	}))
+	defer server.Close()
+
+	mountedDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(mountedDir, "TEST_SECRET"), []byte("mounted\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	report = &ReadReport{}
+	err = ReadConfig(cfg, WithYamlReader(strings.NewReader("StringVal: fromReader\n")), WithReadReport(report), WithEnv(map[string]string{}),
+		WithoutDotenv(), WithHTTPSource(HTTPSource{URL: server.URL, Client: server.Client()}), WithMountedDirs(mountedDir))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expectedSources = map[string]FieldSource{
+		"IntVal":    {Source: "http", Location: server.URL, Line: 2},
+		"SecretVal": {Source: "mounted", Location: filepath.Join(mountedDir, "TEST_SECRET")},
+	}
+	for field, expectedSource := range expectedSources {
+		if report.Fields[field] != expectedSource {
+			t.Errorf("expected source %v for %s, got %v", expectedSource, field, report.Fields[field])
+		}
+	}
+}
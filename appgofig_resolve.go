@@ -0,0 +1,66 @@
+package appgofig
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValueResolver resolves a value reference like file:///run/secrets/db to the actual value.
+// It receives everything after "<scheme>://", e.g. /run/secrets/db
+type ValueResolver func(reference string) (string, error)
+
+// resolveFieldValue resolves the value of the field fieldName if it is a reference of a registered scheme,
+// other values are returned as they are. Resolved fields are recorded in the report so they can be masked in the output
+func resolveFieldValue(fieldName string, value string, gofigOptions *AppGofigOptions) (string, error) {
+	scheme, reference, isReference := strings.Cut(strings.TrimSpace(value), "://")
+	if !isReference {
+		return value, nil
+	}
+
+	resolver, ok := lookupResolver(gofigOptions, scheme)
+	if !ok {
+		return value, nil
+	}
+
+	resolvedValue, err := resolver(reference)
+	if err != nil {
+		return "", fmt.Errorf("unable to resolve %s reference of field %s: %w", scheme, fieldName, err)
+	}
+
+	gofigOptions.Report.ResolvedFields[fieldName] = scheme
+	return resolvedValue, nil
+}
+
+// lookupResolver returns the resolver registered for scheme, falling back to the built-in file and env resolvers if enabled
+func lookupResolver(gofigOptions *AppGofigOptions, scheme string) (ValueResolver, bool) {
+	if resolver, ok := gofigOptions.Resolvers[scheme]; ok {
+		return resolver, true
+	}
+
+	if !gofigOptions.BuiltinResolvers {
+		return nil, false
+	}
+
+	switch scheme {
+	case "file":
+		return func(reference string) (string, error) {
+			data, err := readSecretFile(reference)
+			if err != nil {
+				return "", err
+			}
+
+			return strings.TrimRight(string(data), "\r\n"), nil
+		}, true
+	case "env":
+		return func(reference string) (string, error) {
+			envVal, hasEnvVal := gofigOptions.EnvLookup(reference)
+			if !hasEnvVal {
+				return "", fmt.Errorf("environment variable %s is not set", reference)
+			}
+
+			return envVal, nil
+		}, true
+	default:
+		return nil, false
+	}
+}
@@ -0,0 +1,142 @@
+package appgofig
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestValueReferences(t *testing.T) {
+	t.Parallel()
+
+	// referenced files are read from disk, not from the config file system
+	secretFile := filepath.Join(t.TempDir(), "db")
+	if err := os.WriteFile(secretFile, []byte("dbPassword\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("SecretVal: file://" + secretFile + "\nStringVal: env://INJECTED_KEY\n")},
+	}
+
+	// references are only resolved if enabled
+	cfg := &TestConfig{}
+	err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{"INJECTED_KEY": "injectedValue"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.SecretVal != "file://"+secretFile {
+		t.Errorf("expected SecretVal to be kept without built-in resolvers, got %q", cfg.SecretVal)
+	}
+
+	report := &ReadReport{}
+	cfg = &TestConfig{}
+	err = ReadConfig(cfg, WithFS(fileSystem), WithBuiltinResolvers(), WithReadReport(report), WithEnv(map[string]string{"INJECTED_KEY": "injectedValue"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.SecretVal != "dbPassword" {
+		t.Errorf("expected SecretVal=dbPassword, got %q", cfg.SecretVal)
+	}
+	if cfg.StringVal != "injectedValue" {
+		t.Errorf("expected StringVal=injectedValue, got %s", cfg.StringVal)
+	}
+
+	// masking depends on the report, not on the config struct instance
+	cfgCopy := *cfg
+
+	var sb strings.Builder
+	LogConfig(&cfgCopy, &sb, WithLogReport(report))
+	if strings.Contains(sb.String(), "injectedValue") {
+		t.Errorf("expected resolved value to be masked, got: %s", sb.String())
+	}
+
+	err = ReadConfig(cfg, WithFS(fileSystem), WithBuiltinResolvers(), WithEnv(map[string]string{}))
+	if err == nil {
+		t.Fatal("expected error for unset environment variable reference, got none")
+	}
+}
+
+func TestCustomResolver(t *testing.T) {
+	t.Parallel()
+
+	// a fake secret manager
+	secrets := map[string]string{"db/password": "fromVault"}
+	vaultResolver := func(reference string) (string, error) {
+		secret, ok := secrets[reference]
+		if !ok {
+			return "", fmt.Errorf("secret %s not found", reference)
+		}
+		return secret, nil
+	}
+
+	report := &ReadReport{}
+	cfg := &TestConfig{}
+	err := ReadConfig(cfg, WithoutDotenv(), WithReadReport(report), WithResolver("vault", vaultResolver), WithEnv(map[string]string{
+		"TEST_SECRET": "vault://db/password",
+		"TEST_STRING": "https://example.com",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.SecretVal != "fromVault" {
+		t.Errorf("expected SecretVal=fromVault, got %s", cfg.SecretVal)
+	}
+	if cfg.StringVal != "https://example.com" {
+		t.Errorf("expected unregistered schemes to be kept, got %s", cfg.StringVal)
+	}
+	if report.ResolvedFields["SecretVal"] != "vault" {
+		t.Errorf("expected SecretVal to be reported as resolved by vault, got %v", report.ResolvedFields)
+	}
+
+	err = ReadConfig(cfg, WithoutDotenv(), WithResolver("vault", vaultResolver), WithEnv(map[string]string{"TEST_SECRET": "vault://unknown"}))
+	if err == nil {
+		t.Fatal("expected error for failing resolver, got none")
+	}
+
+	if err := ReadConfig(cfg, WithResolver("", vaultResolver)); err == nil {
+		t.Fatal("expected error for empty scheme, got none")
+	}
+}
+
+func TestErrorsOmitMaskedValues(t *testing.T) {
+	t.Parallel()
+
+	type PortConfig struct {
+		Port     int `env:"PORT"`
+		Password int `env:"PASSWORD" mask:"true"`
+	}
+
+	resolver := WithResolver("vault", func(reference string) (string, error) {
+		return "resolvedSecret", nil
+	})
+
+	err := ReadConfig(&PortConfig{}, WithoutDotenv(), resolver, WithEnv(map[string]string{"PORT": "vault://port"}))
+	if err == nil || strings.Contains(err.Error(), "resolvedSecret") {
+		t.Errorf("expected error without resolved value, got %v", err)
+	}
+
+	err = ReadConfig(&PortConfig{}, WithoutDotenv(), WithEnv(map[string]string{"PASSWORD": "taggedSecret"}))
+	if err == nil || strings.Contains(err.Error(), "taggedSecret") {
+		t.Errorf("expected error without masked value, got %v", err)
+	}
+
+	err = ReadConfig(&PortConfig{}, WithoutDotenv(), WithEnv(map[string]string{"PORT": "notAPort"}))
+	if err == nil || !strings.Contains(err.Error(), "notAPort") {
+		t.Errorf("expected error with unmasked value, got %v", err)
+	}
+
+	type SecretConfig struct {
+		Password string `env:"PASSWORD"`
+	}
+	err = ReadConfig(&SecretConfig{}, WithoutDotenv(), WithInterpolation(), WithEnv(map[string]string{"PASSWORD": "interpolatedSecret${"}))
+	if err == nil || strings.Contains(err.Error(), "interpolatedSecret") {
+		t.Errorf("expected interpolation error without value, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+package appgofig
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ReloadOnSignal reloads the config whenever the process receives one of signals (default: SIGHUP) until ctx is done.
+// Every reload writes the changed fields (masked fields stay masked) or the error to out, a failed reload
+// keeps the previous config and is passed to OnError as well
+func (watcher *Watcher[T]) ReloadOnSignal(ctx context.Context, out io.Writer, signals ...os.Signal) error {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+
+	signalChan := make(chan os.Signal, 1)
+	signal.Notify(signalChan, signals...)
+	defer signal.Stop(signalChan)
+
+	return watcher.reloadOnSignals(ctx, out, signalChan)
+}
+
+// reloadOnSignals implements ReloadOnSignal for an arbitrary signal channel
+func (watcher *Watcher[T]) reloadOnSignals(ctx context.Context, out io.Writer, signalChan <-chan os.Signal) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case receivedSignal := <-signalChan:
+			watcher.reloadMu.Lock()
+			changes, warnings, err := watcher.reload()
+			watcher.reloadMu.Unlock()
+
+			if err != nil {
+				fmt.Fprintf(out, "### AppGofig Reload (%s) Failed: %v ###\n", receivedSignal, err)
+
+				if watcher.OnError != nil {
+					watcher.OnError(err)
+				}
+				continue
+			}
+
+			fmt.Fprintf(out, "### AppGofig Reload (%s): %d changed ###\n", receivedSignal, len(changes))
+			writeFieldChanges(out, changes)
+
+			for _, warning := range warnings {
+				fmt.Fprintf(out, "#| Warning: %s\n", warning)
+			}
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package appgofig
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"syscall"
+	"testing"
+	"testing/fstest"
+)
+
+type signalTestConfig struct {
+	Port     int    `default:"80"`
+	Password string `default:"initial" mask:"true"`
+}
+
+func TestReloadOnSignal(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("Port: 8080\n")},
+	}
+
+	watcher, err := NewWatcher[signalTestConfig](WithFS(fileSystem), WithEnv(map[string]string{}), WithoutDotenv())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// every reload attempt reports back, so the file system is not changed while being read
+	reloaded := make(chan error)
+	watcher.OnReload = func(cfg *signalTestConfig) {
+		reloaded <- nil
+	}
+	watcher.OnError = func(err error) {
+		reloaded <- err
+	}
+
+	var out bytes.Buffer
+	signalChan := make(chan os.Signal)
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- watcher.reloadOnSignals(ctx, &out, signalChan)
+	}()
+
+	fileSystem["config.yaml"] = &fstest.MapFile{Data: []byte("Port: 9090\nPassword: newSecret\n")}
+	signalChan <- syscall.SIGHUP
+	if err := <-reloaded; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// a broken file does not replace the config
+	fileSystem["config.yaml"] = &fstest.MapFile{Data: []byte("Port: abc\n")}
+	signalChan <- syscall.SIGHUP
+	if err := <-reloaded; err == nil {
+		t.Fatal("expected error for invalid port, got none")
+	}
+
+	cancel()
+	if err := <-runErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+
+	if watcher.Config().Port != 9090 {
+		t.Errorf("expected port of the first reload, got %d", watcher.Config().Port)
+	}
+
+	output := out.String()
+	if !strings.Contains(output, "#| Port : 8080 -> 9090\n") {
+		t.Errorf("expected port change to be logged, got:\n%s", output)
+	}
+	if strings.Contains(output, "newSecret") || !strings.Contains(output, "#| Password : ") {
+		t.Errorf("expected password change to be logged masked, got:\n%s", output)
+	}
+	if !strings.Contains(output, "Failed") {
+		t.Errorf("expected failed reload to be logged, got:\n%s", output)
+	}
+}
+
+func TestReloadOnSignalCancelled(t *testing.T) {
+	t.Parallel()
+
+	watcher, err := NewWatcher[signalTestConfig](WithFS(fstest.MapFS{}), WithEnv(map[string]string{}), WithoutDotenv())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if err := watcher.ReloadOnSignal(ctx, &bytes.Buffer{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package appgofig
+
+import (
+	"slices"
+	"sync"
+	"sync/atomic"
+)
+
+// ConfigSubscriber is notified about a published config. oldConfig is nil for the first published config
+type ConfigSubscriber[T any] func(oldConfig *T, newConfig *T, changes FieldChanges)
+
+// Store holds the current config and can safely be shared between goroutines. The zero value is ready to use
+type Store[T any] struct {
+	current atomic.Pointer[storeSnapshot[T]]
+
+	// publishMu serializes Set, so subscribers see the configs in the order they were published
+	publishMu sync.Mutex
+
+	subscribersMu    sync.Mutex
+	subscribers      []storeSubscriber[T]
+	nextSubscriberID int
+}
+
+// storeSnapshot is a published config together with the report of reading it
+type storeSnapshot[T any] struct {
+	config *T
+	report *ReadReport
+}
+
+// storeSubscriber is a registered subscriber of a Store
+type storeSubscriber[T any] struct {
+	id         int
+	subscriber ConfigSubscriber[T]
+}
+
+// NewStore returns a Store holding initialConfig
+func NewStore[T any](initialConfig *T) *Store[T] {
+	store := &Store[T]{}
+	store.current.Store(&storeSnapshot[T]{config: initialConfig})
+
+	return store
+}
+
+// Get returns the current config, nil if none was published yet. The config is a shared snapshot and must not be modified
+func (store *Store[T]) Get() *T {
+	return store.snapshot().config
+}
+
+// Report returns the report of the current config, nil if it was published without one (see SetWithReport)
+func (store *Store[T]) Report() *ReadReport {
+	return store.snapshot().report
+}
+
+// snapshot returns the current snapshot, which is empty if nothing was published yet
+func (store *Store[T]) snapshot() *storeSnapshot[T] {
+	if snapshot := store.current.Load(); snapshot != nil {
+		return snapshot
+	}
+
+	return &storeSnapshot[T]{}
+}
+
+// Set publishes newConfig and notifies all subscribers if at least one field changed. The changes are returned as well.
+// newConfig must not be modified afterwards. Subscribers are called synchronously and must not call Set themselves
+func (store *Store[T]) Set(newConfig *T) FieldChanges {
+	return store.SetWithReport(newConfig, nil)
+}
+
+// SetWithReport works like Set, but keeps report (see WithReadReport) with newConfig,
+// so resolved and decrypted values are masked within the changes
+func (store *Store[T]) SetWithReport(newConfig *T, report *ReadReport) FieldChanges {
+	store.publishMu.Lock()
+	defer store.publishMu.Unlock()
+
+	oldSnapshot := store.snapshot()
+	oldConfig := oldSnapshot.config
+	store.current.Store(&storeSnapshot[T]{config: newConfig, report: report})
+
+	changes := diffConfigs(oldConfig, newConfig, newLogOptions([]LogOption{WithLogReport(oldSnapshot.report), WithLogReport(report)}))
+	if len(changes) == 0 {
+		return changes
+	}
+
+	store.subscribersMu.Lock()
+	subscribers := slices.Clone(store.subscribers)
+	store.subscribersMu.Unlock()
+
+	for _, entry := range subscribers {
+		entry.subscriber(oldConfig, newConfig, changes)
+	}
+
+	return changes
+}
+
+// Subscribe registers subscriber to be notified about every changed config. The returned function removes the subscription
+func (store *Store[T]) Subscribe(subscriber ConfigSubscriber[T]) (unsubscribe func()) {
+	store.subscribersMu.Lock()
+	defer store.subscribersMu.Unlock()
+
+	id := store.nextSubscriberID
+	store.nextSubscriberID++
+	store.subscribers = append(store.subscribers, storeSubscriber[T]{id: id, subscriber: subscriber})
+
+	return func() {
+		store.subscribersMu.Lock()
+		defer store.subscribersMu.Unlock()
+
+		store.subscribers = slices.DeleteFunc(store.subscribers, func(entry storeSubscriber[T]) bool {
+			return entry.id == id
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package appgofig
+
+import (
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+func TestStore(t *testing.T) {
+	t.Parallel()
+
+	store := &Store[TestConfig]{}
+	if store.Get() != nil {
+		t.Fatal("expected empty store to return nil")
+	}
+
+	var notifications []FieldChanges
+	store.Subscribe(func(oldConfig *TestConfig, newConfig *TestConfig, changes FieldChanges) {
+		notifications = append(notifications, changes)
+	})
+
+	first := &TestConfig{StringVal: "first", IntVal: 1, SecretVal: "secret1"}
+	store.Set(first)
+
+	if store.Get() != first {
+		t.Fatal("expected store to return the published config")
+	}
+	if len(notifications) != 1 || len(notifications[0]) != 5 {
+		t.Fatalf("expected every field to be reported for the first config, got %+v", notifications)
+	}
+
+	// publishing an equal config does not notify
+	store.Set(&TestConfig{StringVal: "first", IntVal: 1, SecretVal: "secret1"})
+	if len(notifications) != 1 {
+		t.Fatalf("expected no notification without changes, got %+v", notifications)
+	}
+
+	store.Set(&TestConfig{StringVal: "first", IntVal: 2, SecretVal: "secret2"})
+	if len(notifications) != 2 {
+		t.Fatalf("expected a notification, got %+v", notifications)
+	}
+
+	changes := notifications[1]
+	if !changes.Contains("IntVal") || !changes.Contains("SecretVal") || changes.Contains("StringVal") || len(changes) != 2 {
+		t.Fatalf("expected IntVal and SecretVal to change, got %+v", changes)
+	}
+
+	expectedChange := FieldChange{Field: "IntVal", EnvKey: "TEST_INT", OldValue: "1", NewValue: "2", Masked: false}
+	if changes[0] != expectedChange {
+		t.Errorf("expected %+v, got %+v", expectedChange, changes[0])
+	}
+	if !changes[1].Masked || changes[1].OldValue == "secret1" || changes[1].NewValue == "secret2" {
+		t.Errorf("expected secret change to be masked, got %+v", changes[1])
+	}
+}
+
+func TestStoreUnsubscribe(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore(&TestConfig{IntVal: 1})
+
+	calls := 0
+	unsubscribe := store.Subscribe(func(oldConfig *TestConfig, newConfig *TestConfig, changes FieldChanges) {
+		calls++
+	})
+
+	store.Set(&TestConfig{IntVal: 2})
+	unsubscribe()
+	store.Set(&TestConfig{IntVal: 3})
+
+	if calls != 1 {
+		t.Errorf("expected 1 notification, got %d", calls)
+	}
+}
+
+func TestStoreConcurrentGet(t *testing.T) {
+	t.Parallel()
+
+	store := NewStore(&TestConfig{IntVal: 0})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Go(func() {
+			for j := 0; j < 100; j++ {
+				if store.Get() == nil {
+					t.Error("expected a config")
+					return
+				}
+			}
+		})
+	}
+
+	for i := 1; i <= 100; i++ {
+		store.Set(&TestConfig{IntVal: i})
+	}
+
+	wg.Wait()
+}
+
+func TestWatcherNotifiesSubscribers(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("Port: 8080\n"), ModTime: time.Unix(1, 0)},
+	}
+
+	watcher, err := NewWatcher[watchTestConfig](WithFS(fileSystem), WithEnv(map[string]string{}), WithoutDotenv())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var receivedChanges FieldChanges
+	watcher.Store().Subscribe(func(oldConfig *watchTestConfig, newConfig *watchTestConfig, changes FieldChanges) {
+		receivedChanges = changes
+	})
+
+	fileSystem["config.yaml"] = &fstest.MapFile{Data: []byte("Port: 8080\nMode: prod\n"), ModTime: time.Unix(2, 0)}
+	if err := watcher.reloadIfChanged(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(receivedChanges) != 1 || receivedChanges[0].Field != "Mode" || receivedChanges[0].NewValue != "prod" {
+		t.Errorf("expected only Mode to change, got %+v", receivedChanges)
+	}
+}
@@ -6,8 +6,10 @@ package appgofig
 
 import (
 	"os"
+	"slices"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 type TestConfig struct {
@@ -146,6 +148,19 @@ func TestYamlExampleAndMarkdownGeneration(t *testing.T) {
 	if _, err := os.Stat(yamlFile); err != nil {
 		t.Fatalf("YAML example file not created: %v", err)
 	}
+
+	mdContent, _ := os.ReadFile(mdFile)
+	if !strings.Contains(string(mdContent), "TEST_SECRET (or TEST_SECRET_FILE)") {
+		t.Errorf("expected markdown to mention TEST_SECRET_FILE, got: %s", mdContent)
+	}
+	if !strings.Contains(string(mdContent), "| Restart Required |") || !strings.Contains(string(mdContent), "| IntVal | TEST_INT | int | no | no | 42 |") {
+		t.Errorf("expected markdown to contain the restart required column, got: %s", mdContent)
+	}
+
+	yamlContent, _ := os.ReadFile(yamlFile)
+	if !strings.Contains(string(yamlContent), "TEST_SECRET_FILE") {
+		t.Errorf("expected yaml example to mention TEST_SECRET_FILE, got: %s", yamlContent)
+	}
 }
 
 func TestReadModeEnvOnly(t *testing.T) {
@@ -360,3 +375,584 @@ func TestFloatParsingErrors(t *testing.T) {
 		t.Errorf("unexpected error: %v", err)
 	}
 }
+
+func TestDotenvFilesPrecedence(t *testing.T) {
+	resetEnv()
+	defer resetEnv()
+
+	dir := t.TempDir()
+	baseFile := dir + "/.env"
+	localFile := dir + "/.env.local"
+
+	os.WriteFile(baseFile, []byte("TEST_STRING=base\nTEST_INT=1\nTEST_FLOAT=1.5\n"), 0o600)
+	os.WriteFile(localFile, []byte("TEST_INT=2\n"), 0o600)
+	os.Setenv("TEST_FLOAT", "2.5")
+
+	cfg := &TestConfig{}
+	err := ReadConfig(cfg, WithReadMode(ReadModeEnvOnly), WithDotenvFiles(baseFile, localFile, dir+"/.env.missing"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.StringVal != "base" {
+		t.Errorf("expected StringVal=base, got %s", cfg.StringVal)
+	}
+	if cfg.IntVal != 2 {
+		t.Errorf("expected IntVal=2, got %d", cfg.IntVal)
+	}
+	if cfg.FloatVal != 2.5 {
+		t.Errorf("expected FloatVal=2.5, got %v", cfg.FloatVal)
+	}
+}
+
+func TestDotenvFilesInvalid(t *testing.T) {
+	resetEnv()
+	defer resetEnv()
+
+	dir := t.TempDir()
+	brokenFile := dir + "/.env"
+	os.WriteFile(brokenFile, []byte("TEST_STRING=\"unterminated\n"), 0o600)
+
+	cfg := &TestConfig{}
+	if err := ReadConfig(cfg, WithDotenvFiles(brokenFile)); err == nil {
+		t.Fatal("expected error for unparsable dotenv file, got none")
+	}
+
+	if err := ReadConfig(cfg, WithDotenvFiles()); err == nil {
+		t.Fatal("expected error for empty dotenv file list, got none")
+	}
+
+	if err := ReadConfig(cfg, WithDotenvFiles(brokenFile), WithoutDotenv()); err == nil {
+		t.Fatal("expected error when combining dotenv files with disabled dotenv, got none")
+	}
+
+	if err := ReadConfig(cfg, WithReadMode(ReadModeYamlOnly), WithDotenvFiles(brokenFile)); err == nil {
+		t.Fatal("expected error when combining dotenv files with ReadModeYamlOnly, got none")
+	}
+
+	if err := ReadConfig(cfg, WithoutDotenv()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
+func TestDotenvDoesNotModifyEnvironment(t *testing.T) {
+	resetEnv()
+	defer resetEnv()
+
+	dotenvFile := t.TempDir() + "/.env"
+	os.WriteFile(dotenvFile, []byte("TEST_STRING=fromDotenv\n"), 0o600)
+
+	cfg := &TestConfig{}
+	if err := ReadConfig(cfg, WithReadMode(ReadModeEnvOnly), WithDotenvFiles(dotenvFile)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.StringVal != "fromDotenv" {
+		t.Errorf("expected StringVal=fromDotenv, got %s", cfg.StringVal)
+	}
+	if _, exists := os.LookupEnv("TEST_STRING"); exists {
+		t.Error("expected TEST_STRING to not be set in the process environment")
+	}
+}
+
+func TestWithEnv(t *testing.T) {
+	t.Parallel()
+
+	cfg := &TestConfig{}
+	err := ReadConfig(cfg, WithReadMode(ReadModeEnvOnly), WithoutDotenv(), WithEnv(map[string]string{
+		"TEST_STRING": "injected",
+		"TEST_INT":    "5",
+	}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.StringVal != "injected" {
+		t.Errorf("expected StringVal=injected, got %s", cfg.StringVal)
+	}
+	if cfg.IntVal != 5 {
+		t.Errorf("expected IntVal=5, got %d", cfg.IntVal)
+	}
+	if cfg.BoolVal != true {
+		t.Errorf("expected BoolVal=true, got %v", cfg.BoolVal)
+	}
+}
+
+func TestWithEnvLookup(t *testing.T) {
+	t.Parallel()
+
+	lookedUp := []string{}
+	envLookup := func(key string) (string, bool) {
+		lookedUp = append(lookedUp, key)
+		return "", false
+	}
+
+	cfg := &TestConfig{}
+	if err := ReadConfig(cfg, WithReadMode(ReadModeEnvOnly), WithoutDotenv(), WithEnvLookup(envLookup)); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, key := range []string{"TEST_STRING", "TEST_INT", "TEST_BOOL", "TEST_SECRET", "TEST_FLOAT"} {
+		if !slices.Contains(lookedUp, key) {
+			t.Errorf("expected %s to be looked up, got %v", key, lookedUp)
+		}
+	}
+
+	if err := ReadConfig(cfg, WithEnvLookup(nil)); err == nil {
+		t.Fatal("expected error for nil env lookup, got none")
+	}
+}
+
+func TestWithFS(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config/config.yaml": {Data: []byte("IntVal: 3000\nStringVal: fromFS\n")},
+		".env":               {Data: []byte("TEST_FLOAT=3.5\n")},
+		"custom/.env.local":  {Data: []byte("TEST_BOOL=false\n")},
+	}
+
+	cfg := &TestConfig{}
+	if err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{})); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.StringVal != "fromFS" {
+		t.Errorf("expected StringVal=fromFS, got %s", cfg.StringVal)
+	}
+	if cfg.IntVal != 3000 {
+		t.Errorf("expected IntVal=3000, got %d", cfg.IntVal)
+	}
+	if cfg.FloatVal != 3.5 {
+		t.Errorf("expected FloatVal=3.5, got %v", cfg.FloatVal)
+	}
+
+	cfg = &TestConfig{}
+	err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithDotenvFiles("/custom/.env.local"), WithYamlFile("./config/config.yaml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.BoolVal != false {
+		t.Errorf("expected BoolVal=false, got %v", cfg.BoolVal)
+	}
+	if cfg.FloatVal != 0.1 {
+		t.Errorf("expected FloatVal=0.1, got %v", cfg.FloatVal)
+	}
+
+	if err := ReadConfig(cfg, WithFS(fileSystem), WithYamlFile("missing.yaml")); err == nil {
+		t.Fatal("expected error for missing yaml file, got none")
+	}
+}
+
+func TestYamlFilesLayering(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"base.yaml":     {Data: []byte("IntVal: 1\nStringVal: base\n")},
+		"override.yaml": {Data: []byte("IntVal: 2\n")},
+	}
+
+	cfg := &TestConfig{}
+	err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithYamlFiles(
+		YamlFile{Path: "base.yaml"},
+		YamlFile{Path: "missing.yaml", Optional: true},
+		YamlFile{Path: "override.yaml"},
+	))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.StringVal != "base" {
+		t.Errorf("expected StringVal=base, got %s", cfg.StringVal)
+	}
+	if cfg.IntVal != 2 {
+		t.Errorf("expected IntVal=2, got %d", cfg.IntVal)
+	}
+
+	err = ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithYamlFiles(
+		YamlFile{Path: "base.yaml"},
+		YamlFile{Path: "missing.yaml"},
+	))
+	if err == nil {
+		t.Fatal("expected error for missing required yaml file, got none")
+	}
+
+	if err := ReadConfig(cfg, WithYamlFiles()); err == nil {
+		t.Fatal("expected error for empty yaml file list, got none")
+	}
+
+	if err := ReadConfig(cfg, WithYamlFile("base.yaml"), WithYamlFiles(YamlFile{Path: "override.yaml"})); err == nil {
+		t.Fatal("expected error when combining WithYamlFile and WithYamlFiles, got none")
+	}
+}
+
+func TestProfile(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config/config.yaml":            {Data: []byte("IntVal: 1\nStringVal: base\n")},
+		"config/config.production.yaml": {Data: []byte("IntVal: 2\n")},
+	}
+
+	cfg := &TestConfig{}
+	if err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithProfile("production")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.StringVal != "base" {
+		t.Errorf("expected StringVal=base, got %s", cfg.StringVal)
+	}
+	if cfg.IntVal != 2 {
+		t.Errorf("expected IntVal=2, got %d", cfg.IntVal)
+	}
+
+	cfg = &TestConfig{}
+	if err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithProfile("staging")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.IntVal != 1 {
+		t.Errorf("expected IntVal=1, got %d", cfg.IntVal)
+	}
+
+	if err := ReadConfig(cfg, WithProfile("")); err == nil {
+		t.Fatal("expected error for empty profile, got none")
+	}
+}
+
+func TestProfileWithYamlFiles(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"base.yaml":                {Data: []byte("IntVal: 1\nStringVal: base\n")},
+		"base.production.yaml":     {Data: []byte("IntVal: 2\nStringVal: baseProduction\n")},
+		"override.yaml":            {Data: []byte("IntVal: 3\n")},
+		"override.production.yaml": {Data: []byte("BoolVal: false\n")},
+	}
+
+	cfg := &TestConfig{}
+	report := &ReadReport{}
+	err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithReadReport(report), WithProfile("production"),
+		WithYamlFiles(YamlFile{Path: "base.yaml"}, YamlFile{Path: "override.yaml"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the profile of base.yaml must not overwrite override.yaml
+	if cfg.IntVal != 3 {
+		t.Errorf("expected IntVal=3, got %d", cfg.IntVal)
+	}
+	if cfg.StringVal != "baseProduction" {
+		t.Errorf("expected StringVal=baseProduction, got %s", cfg.StringVal)
+	}
+	if cfg.BoolVal {
+		t.Errorf("expected BoolVal=false, got %t", cfg.BoolVal)
+	}
+
+	expectedFiles := []string{"base.yaml", "base.production.yaml", "override.yaml", "override.production.yaml"}
+	if !slices.Equal(report.YamlFiles, expectedFiles) {
+		t.Errorf("expected yaml files %v, got %v", expectedFiles, report.YamlFiles)
+	}
+}
+
+func TestDropInDir(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"etc/myapp/config.yaml":            {Data: []byte("IntVal: 1\nStringVal: base\nBoolVal: false\n")},
+		"etc/myapp/conf.d/10-int.yaml":     {Data: []byte("IntVal: 10\n")},
+		"etc/myapp/conf.d/20-int.yml":      {Data: []byte("IntVal: 20\nStringVal: fragment\n")},
+		"etc/myapp/conf.d/README":          {Data: []byte("IntVal: 30\n")},
+		"etc/myapp/conf.d/nested/30.yaml":  {Data: []byte("IntVal: 30\n")},
+		"etc/myapp/conf.d/05-float.yaml":   {Data: []byte("FloatVal: 0.5\n")},
+		"etc/myapp/conf.d/25-broken.yaml~": {Data: []byte("IntVal: [\n")},
+	}
+
+	report := &ReadReport{}
+	cfg := &TestConfig{}
+	err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithReadReport(report),
+		WithYamlFile("/etc/myapp/config.yaml"), WithDropInDir("/etc/myapp/conf.d"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.IntVal != 20 {
+		t.Errorf("expected IntVal=20, got %d", cfg.IntVal)
+	}
+	if cfg.StringVal != "fragment" {
+		t.Errorf("expected StringVal=fragment, got %s", cfg.StringVal)
+	}
+	if cfg.FloatVal != 0.5 {
+		t.Errorf("expected FloatVal=0.5, got %v", cfg.FloatVal)
+	}
+
+	expectedSources := map[string]string{
+		"IntVal":    "/etc/myapp/conf.d/20-int.yml",
+		"StringVal": "/etc/myapp/conf.d/20-int.yml",
+		"BoolVal":   "/etc/myapp/config.yaml",
+		"FloatVal":  "/etc/myapp/conf.d/05-float.yaml",
+	}
+	for key, expectedSource := range expectedSources {
+		if report.YamlSources[key] != expectedSource {
+			t.Errorf("expected %s to be set by %s, got %s", key, expectedSource, report.YamlSources[key])
+		}
+	}
+
+	if err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithDropInDir("/missing/conf.d")); err != nil {
+		t.Fatalf("unexpected error for missing drop-in directory: %v", err)
+	}
+}
+
+func TestMountedDirs(t *testing.T) {
+	t.Parallel()
+
+	// mimic the layout of a mounted Kubernetes Secret
+	secretDir := t.TempDir()
+	os.MkdirAll(secretDir+"/..2026_01_01_00_00_00.000000000", 0o700)
+	os.Symlink("..2026_01_01_00_00_00.000000000", secretDir+"/..data")
+	os.WriteFile(secretDir+"/..data/TEST_SECRET", []byte("mounted-secret\n"), 0o600)
+	os.Symlink("..data/TEST_SECRET", secretDir+"/TEST_SECRET")
+
+	configMapDir := t.TempDir()
+	os.WriteFile(configMapDir+"/IntVal", []byte("77\n\n"), 0o600)
+	os.WriteFile(configMapDir+"/StringVal", []byte("fromConfigMap"), 0o600)
+
+	report := &ReadReport{}
+	cfg := &TestConfig{}
+	err := ReadConfig(cfg, WithEnv(map[string]string{"TEST_STRING": "fromEnv"}), WithoutDotenv(), WithReadReport(report),
+		WithMountedDirs(configMapDir, secretDir, t.TempDir()+"/missing"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.SecretVal != "mounted-secret" {
+		t.Errorf("expected SecretVal=mounted-secret, got %q", cfg.SecretVal)
+	}
+	if cfg.IntVal != 77 {
+		t.Errorf("expected IntVal=77, got %d", cfg.IntVal)
+	}
+	if cfg.StringVal != "fromConfigMap" {
+		t.Errorf("expected StringVal=fromConfigMap, got %s", cfg.StringVal)
+	}
+	if report.MountedSources["SecretVal"] != secretDir+"/TEST_SECRET" {
+		t.Errorf("expected SecretVal to be reported from %s, got %s", secretDir+"/TEST_SECRET", report.MountedSources["SecretVal"])
+	}
+
+	// mounted directories are read from disk even when reading the config files from another file system
+	cfg = &TestConfig{}
+	err = ReadConfig(cfg, WithFS(fstest.MapFS{}), WithEnv(map[string]string{}), WithMountedDirs(secretDir))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.SecretVal != "mounted-secret" {
+		t.Errorf("expected SecretVal=mounted-secret with WithFS, got %q", cfg.SecretVal)
+	}
+
+	if err := ReadConfig(cfg, WithMountedDirs("")); err == nil {
+		t.Fatal("expected error for empty mounted directory path, got none")
+	}
+}
+
+func TestFileSuffixEnv(t *testing.T) {
+	t.Parallel()
+
+	secretFile := t.TempDir() + "/secret"
+	os.WriteFile(secretFile, []byte("fromFile\n"), 0o600)
+
+	cfg := &TestConfig{}
+	err := ReadConfig(cfg, WithoutDotenv(), WithEnv(map[string]string{"TEST_SECRET_FILE": secretFile}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.SecretVal != "fromFile" {
+		t.Errorf("expected SecretVal=fromFile, got %q", cfg.SecretVal)
+	}
+
+	err = ReadConfig(cfg, WithoutDotenv(), WithEnv(map[string]string{"TEST_SECRET_FILE": secretFile, "TEST_SECRET": "direct"}))
+	if err == nil {
+		t.Fatal("expected error when both TEST_SECRET and TEST_SECRET_FILE are set, got none")
+	}
+
+	err = ReadConfig(cfg, WithoutDotenv(), WithEnv(map[string]string{"TEST_SECRET_FILE": secretFile + ".missing"}))
+	if err == nil {
+		t.Fatal("expected error for missing secret file, got none")
+	}
+
+	// secret files are read from disk even when reading the config files from another file system
+	cfg = &TestConfig{}
+	err = ReadConfig(cfg, WithoutDotenv(), WithFS(fstest.MapFS{}), WithEnv(map[string]string{"TEST_SECRET_FILE": secretFile}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.SecretVal != "fromFile" {
+		t.Errorf("expected SecretVal=fromFile, got %q", cfg.SecretVal)
+	}
+
+	// the environment beats the dotenv file, no matter which of the two keys is used
+	dotenvFile := fstest.MapFS{".env": {Data: []byte("TEST_SECRET_FILE=" + secretFile + "\n")}}
+	cfg = &TestConfig{}
+	err = ReadConfig(cfg, WithFS(dotenvFile), WithEnv(map[string]string{"TEST_SECRET": "direct"}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.SecretVal != "direct" {
+		t.Errorf("expected SecretVal=direct, got %q", cfg.SecretVal)
+	}
+
+	cfg = &TestConfig{}
+	err = ReadConfig(cfg, WithFS(fstest.MapFS{".env": {Data: []byte("TEST_SECRET=dotenv\n")}}), WithEnv(map[string]string{"TEST_SECRET_FILE": secretFile}))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.SecretVal != "fromFile" {
+		t.Errorf("expected SecretVal=fromFile, got %q", cfg.SecretVal)
+	}
+
+	err = ReadConfig(cfg, WithFS(fstest.MapFS{".env": {Data: []byte("TEST_SECRET=dotenv\nTEST_SECRET_FILE=" + secretFile + "\n")}}), WithEnv(map[string]string{}))
+	if err == nil {
+		t.Fatal("expected error when both keys are set within the same dotenv file, got none")
+	}
+}
+
+func TestReaders(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("IntVal: 1\n")},
+	}
+
+	cfg := &TestConfig{}
+	err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}),
+		WithYamlReader(strings.NewReader("StringVal: fromYamlReader\n")),
+		WithJSONReader(strings.NewReader(`{"BoolVal": false}`)),
+		WithDotenvReader(strings.NewReader("TEST_FLOAT=4.5\n")),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.StringVal != "fromYamlReader" {
+		t.Errorf("expected StringVal=fromYamlReader, got %s", cfg.StringVal)
+	}
+	if cfg.BoolVal != false {
+		t.Errorf("expected BoolVal=false, got %v", cfg.BoolVal)
+	}
+	if cfg.FloatVal != 4.5 {
+		t.Errorf("expected FloatVal=4.5, got %v", cfg.FloatVal)
+	}
+	// readers replace the discovery of config.yaml
+	if cfg.IntVal != 42 {
+		t.Errorf("expected IntVal=42, got %d", cfg.IntVal)
+	}
+
+	cfg = &TestConfig{}
+	err = ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithYamlFile("config.yaml"),
+		WithYamlReader(strings.NewReader("IntVal: 2\n")))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.IntVal != 2 {
+		t.Errorf("expected IntVal=2, got %d", cfg.IntVal)
+	}
+
+	if err := ReadConfig(cfg, WithYamlReader(strings.NewReader("IntVal: [\n"))); err == nil {
+		t.Fatal("expected error for invalid yaml reader, got none")
+	}
+
+	if err := ReadConfig(cfg, WithReadMode(ReadModeEnvOnly), WithYamlReader(strings.NewReader(""))); err == nil {
+		t.Fatal("expected error when combining yaml reader with ReadModeEnvOnly, got none")
+	}
+}
+
+func TestSearchPaths(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"etc/myapp/myapp.yaml":   {Data: []byte("IntVal: 1\n")},
+		"home/.config/myapp.yml": {Data: []byte("IntVal: 2\n")},
+		"custom/app.yaml":        {Data: []byte("IntVal: 3\n")},
+	}
+
+	report := &ReadReport{}
+	cfg := &TestConfig{}
+	err := ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithReadReport(report),
+		WithSearchDirs("", "/home/.config", SystemConfigDir("myapp")), WithConfigNames("myapp.yml", "myapp.yaml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.IntVal != 2 {
+		t.Errorf("expected IntVal=2, got %d", cfg.IntVal)
+	}
+	if report.ConfigFile != "/home/.config/myapp.yml" {
+		t.Errorf("expected ConfigFile=/home/.config/myapp.yml, got %s", report.ConfigFile)
+	}
+
+	err = ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{"APP_CONFIG": "/custom/app.yaml"}), WithReadReport(report),
+		WithSearchDirs("/etc/myapp"), WithConfigNames("myapp.yaml"), WithConfigPathEnv("APP_CONFIG"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.IntVal != 3 {
+		t.Errorf("expected IntVal=3, got %d", cfg.IntVal)
+	}
+	if report.ConfigFile != "/custom/app.yaml" {
+		t.Errorf("expected ConfigFile=/custom/app.yaml, got %s", report.ConfigFile)
+	}
+
+	err = ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{"APP_CONFIG": "/custom/missing.yaml"}), WithConfigPathEnv("APP_CONFIG"))
+	if err == nil {
+		t.Fatal("expected error for missing file referenced by APP_CONFIG, got none")
+	}
+
+	if err := ReadConfig(cfg, WithConfigNames()); err == nil {
+		t.Fatal("expected error for empty config names, got none")
+	}
+}
+
+func TestRequiredYamlFile(t *testing.T) {
+	t.Parallel()
+
+	report := &ReadReport{}
+	cfg := &TestConfig{}
+	err := ReadConfig(cfg, WithFS(fstest.MapFS{}), WithEnv(map[string]string{}), WithReadReport(report))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.YamlFileFound() {
+		t.Errorf("expected no yaml file to be found, got %v", report.YamlFiles)
+	}
+	if len(report.SearchedPaths) != 4 {
+		t.Errorf("expected 4 searched paths, got %v", report.SearchedPaths)
+	}
+
+	err = ReadConfig(cfg, WithFS(fstest.MapFS{}), WithEnv(map[string]string{}), WithReadMode(ReadModeYamlOnly), WithRequiredYamlFile())
+	if err == nil || !strings.Contains(err.Error(), "config/config.yaml") {
+		t.Fatalf("expected error listing the searched paths, got %v", err)
+	}
+
+	fileSystem := fstest.MapFS{
+		"config/config.yml": {Data: []byte("IntVal: 1\n")},
+	}
+	err = ReadConfig(cfg, WithFS(fileSystem), WithEnv(map[string]string{}), WithRequiredYamlFile(), WithReadReport(report))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !report.YamlFileFound() || report.YamlFiles[0] != "config/config.yml" {
+		t.Errorf("expected config/config.yml to be loaded, got %v", report.YamlFiles)
+	}
+
+	if err := ReadConfig(cfg, WithReadMode(ReadModeEnvOnly), WithRequiredYamlFile()); err == nil {
+		t.Fatal("expected error when requiring a yaml file with ReadModeEnvOnly, got none")
+	}
+}
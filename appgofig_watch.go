@@ -0,0 +1,345 @@
+package appgofig
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"iter"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultWatchInterval is used by Watcher if no interval is specified
+const defaultWatchInterval = time.Second
+
+// RestartRequiredPolicy decides how a Watcher handles reloads changing fields tagged with reload:"false"
+type RestartRequiredPolicy string
+
+const (
+	// RejectRestartRequired rejects the whole reload, keeping the previous config
+	RejectRestartRequired RestartRequiredPolicy = "reject"
+	// PinRestartRequired applies the reload, but keeps the previous value of such fields and reports a warning
+	PinRestartRequired RestartRequiredPolicy = "pin"
+)
+
+// Validator can be implemented by config structs to reject a reloaded config before it is published, see Watcher
+type Validator interface {
+	Validate() error
+}
+
+// Watcher keeps a config up to date by re-reading it whenever one of its yaml files changes, see NewWatcher
+type Watcher[T any] struct {
+	// Interval between two checks of the watched files, defaults to 1 second
+	Interval time.Duration
+	// OnError is called with every failed reload, the previous config stays in place. Optional
+	OnError func(err error)
+	// OnReload is called with every newly published config. Optional
+	OnReload func(cfg *T)
+	// RestartRequired decides what happens if a reload changes a field tagged with reload:"false", defaults to RejectRestartRequired
+	RestartRequired RestartRequiredPolicy
+	// OnWarning is called for every field pinned by PinRestartRequired. Optional
+	OnWarning func(warning string)
+
+	optionList   []AppGofigOption
+	gofigOptions *AppGofigOptions
+	store        *Store[T]
+
+	// reloadMu serializes reloads and guards watchedFiles
+	reloadMu     sync.Mutex
+	watchedFiles map[string]watchedFileState
+}
+
+// watchedFileState is used to detect changes of a watched file
+type watchedFileState struct {
+	exists  bool
+	modTime time.Time
+	size    int64
+}
+
+// NewWatcher reads the config using optionList like ReadConfig and returns a Watcher providing it.
+// Call Run to pick up changes of the yaml files
+func NewWatcher[T any](optionList ...AppGofigOption) (*Watcher[T], error) {
+	watcher := &Watcher[T]{
+		Interval:        defaultWatchInterval,
+		RestartRequired: RejectRestartRequired,
+		optionList:      optionList,
+		gofigOptions:    &AppGofigOptions{},
+		store:           &Store[T]{},
+	}
+
+	// the options are only needed to stat the watched files the same way ReadConfig reads them
+	for _, opt := range optionList {
+		opt(watcher.gofigOptions)
+	}
+
+	// readers are consumed by the first read, so their content is buffered and replayed on every reload
+	replayReaders, err := bufferReaders(watcher.gofigOptions)
+	if err != nil {
+		return nil, err
+	}
+	watcher.optionList = append(slices.Clone(optionList), replayReaders)
+
+	if err := watcher.Reload(); err != nil {
+		return nil, err
+	}
+
+	return watcher, nil
+}
+
+// bufferReaders reads the yaml and dotenv readers of gofigOptions and returns an option replacing them
+// with fresh readers of the buffered content
+func bufferReaders(gofigOptions *AppGofigOptions) (AppGofigOption, error) {
+	yamlData, err := readAllReaders(gofigOptions.YamlReaders)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read yaml reader: %w", err)
+	}
+
+	dotenvData, err := readAllReaders(gofigOptions.DotenvReaders)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read dotenv reader: %w", err)
+	}
+
+	return func(options *AppGofigOptions) {
+		options.YamlReaders = replayReaders(yamlData)
+		options.DotenvReaders = replayReaders(dotenvData)
+	}, nil
+}
+
+// readAllReaders returns the content of every reader, nil for nil readers so ReadConfig still rejects them
+func readAllReaders(readers []io.Reader) ([][]byte, error) {
+	contents := [][]byte{}
+	for _, reader := range readers {
+		if reader == nil {
+			contents = append(contents, nil)
+			continue
+		}
+
+		data, err := io.ReadAll(reader)
+		if err != nil {
+			return nil, err
+		}
+
+		contents = append(contents, data)
+	}
+
+	return contents, nil
+}
+
+// replayReaders returns a new reader per content, see readAllReaders
+func replayReaders(contents [][]byte) []io.Reader {
+	readers := []io.Reader{}
+	for _, data := range contents {
+		if data == nil {
+			readers = append(readers, nil)
+			continue
+		}
+
+		readers = append(readers, bytes.NewReader(data))
+	}
+
+	return readers
+}
+
+// Config returns the currently published config. It must not be modified, as it may be shared between goroutines
+func (watcher *Watcher[T]) Config() *T {
+	return watcher.store.Get()
+}
+
+// Store returns the store every reloaded config is published to, e.g. to subscribe to changes
+func (watcher *Watcher[T]) Store() *Store[T] {
+	return watcher.store
+}
+
+// Reload reads the config into a fresh struct and validates it. Only if both succeed, the new config is published.
+// Otherwise the previous config stays in place and the error is returned
+func (watcher *Watcher[T]) Reload() error {
+	watcher.reloadMu.Lock()
+	defer watcher.reloadMu.Unlock()
+
+	_, _, err := watcher.reload()
+	return err
+}
+
+// reload implements Reload and returns the published changes and warnings, the caller has to hold reloadMu
+func (watcher *Watcher[T]) reload() (FieldChanges, []string, error) {
+	newConfig := new(T)
+
+	_, report, err := readConfig(newConfig, watcher.optionList)
+
+	// watch the files of this attempt, so fixing a broken file triggers the next reload
+	if report != nil {
+		watcher.watchedFiles = watcher.snapshotFiles(watchedPaths(report, watcher.gofigOptions))
+	}
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to reload config: %w", err)
+	}
+
+	// a report passed using WithReadReport is overwritten by the next reload, so the store keeps a copy
+	reportCopy := *report
+
+	warnings, err := watcher.applyRestartRequired(newConfig, &reportCopy)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if validator, ok := any(newConfig).(Validator); ok {
+		if err := validator.Validate(); err != nil {
+			return nil, nil, fmt.Errorf("reloaded config is not valid: %w", err)
+		}
+	}
+
+	changes := watcher.store.SetWithReport(newConfig, &reportCopy)
+
+	if watcher.OnReload != nil {
+		watcher.OnReload(newConfig)
+	}
+
+	return changes, warnings, nil
+}
+
+// applyRestartRequired handles changed fields tagged with reload:"false" according to the RestartRequired policy.
+// It either returns an error or pins the fields of newConfig to their current values and returns a warning per field
+func (watcher *Watcher[T]) applyRestartRequired(newConfig *T, newReport *ReadReport) ([]string, error) {
+	currentConfig := watcher.store.Get()
+	if currentConfig == nil {
+		return nil, nil
+	}
+
+	logOptions := newLogOptions([]LogOption{WithLogReport(watcher.store.Report()), WithLogReport(newReport)})
+
+	t := reflect.TypeFor[T]()
+	restartChanges := FieldChanges{}
+	for _, change := range diffConfigs(currentConfig, newConfig, logOptions) {
+		if field, _ := t.FieldByName(change.Field); requiresRestart(field) {
+			restartChanges = append(restartChanges, change)
+		}
+	}
+
+	if len(restartChanges) == 0 {
+		return nil, nil
+	}
+
+	if watcher.RestartRequired != PinRestartRequired {
+		fieldNames := []string{}
+		for _, change := range restartChanges {
+			fieldNames = append(fieldNames, change.Field)
+		}
+
+		return nil, fmt.Errorf("reload rejected, changing %s requires a restart", strings.Join(fieldNames, ", "))
+	}
+
+	warnings := []string{}
+	for _, change := range restartChanges {
+		currentValue := reflect.ValueOf(currentConfig).Elem().FieldByName(change.Field)
+		reflect.ValueOf(newConfig).Elem().FieldByName(change.Field).Set(currentValue)
+
+		warning := fmt.Sprintf("changing %s requires a restart, keeping the previous value", change.Field)
+		if !change.Masked {
+			warning = fmt.Sprintf("changing %s requires a restart, keeping %s instead of %s", change.Field, change.OldValue, change.NewValue)
+		}
+		warnings = append(warnings, warning)
+
+		if watcher.OnWarning != nil {
+			watcher.OnWarning(warning)
+		}
+	}
+
+	return warnings, nil
+}
+
+// Run checks the watched files every Interval and reloads the config if one of them changed, until ctx is done
+func (watcher *Watcher[T]) Run(ctx context.Context) error {
+	interval := watcher.Interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+			if err := watcher.reloadIfChanged(); err != nil && watcher.OnError != nil {
+				watcher.OnError(err)
+			}
+		}
+	}
+}
+
+// reloadIfChanged reloads the config if at least one watched file changed since the last reload
+func (watcher *Watcher[T]) reloadIfChanged() error {
+	watcher.reloadMu.Lock()
+	defer watcher.reloadMu.Unlock()
+
+	currentFiles := watcher.snapshotFiles(maps.Keys(watcher.watchedFiles))
+	if maps.Equal(currentFiles, watcher.watchedFiles) {
+		return nil
+	}
+
+	_, _, err := watcher.reload()
+	return err
+}
+
+// snapshotFiles returns the current state of every file in filePaths
+func (watcher *Watcher[T]) snapshotFiles(filePaths iter.Seq[string]) map[string]watchedFileState {
+	fileStates := make(map[string]watchedFileState)
+
+	for filePath := range filePaths {
+		info, err := statConfigFile(watcher.gofigOptions, filePath)
+		if err != nil {
+			fileStates[filePath] = watchedFileState{}
+			continue
+		}
+
+		fileStates[filePath] = watchedFileState{exists: true, modTime: info.ModTime(), size: info.Size()}
+	}
+
+	return fileStates
+}
+
+// watchedPaths returns every file whose change could lead to a different config: the loaded and the explicitly
+// specified yaml files, the discovery candidates (a config file might appear) and the drop-in directory (fragments might be added)
+func watchedPaths(report *ReadReport, gofigOptions *AppGofigOptions) iter.Seq[string] {
+	return func(yield func(string) bool) {
+		for _, yamlFile := range report.YamlFiles {
+			// readers cannot change
+			if yamlFile == "reader" {
+				continue
+			}
+
+			if !yield(yamlFile) {
+				return
+			}
+		}
+
+		// explicitly specified files might be missing right now
+		if gofigOptions.YamlFileRequested && !yield(gofigOptions.YamlFilePath) {
+			return
+		}
+
+		for _, yamlFile := range gofigOptions.YamlFiles {
+			if !yield(yamlFile.Path) {
+				return
+			}
+		}
+
+		for _, searchedPath := range report.SearchedPaths {
+			if !yield(searchedPath) {
+				return
+			}
+		}
+
+		if gofigOptions.DropInDirRequested {
+			yield(gofigOptions.DropInDir)
+		}
+	}
+}
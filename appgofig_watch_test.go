@@ -0,0 +1,258 @@
+package appgofig
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+type watchTestConfig struct {
+	Port int    `default:"80"`
+	Mode string `default:"dev"`
+}
+
+func (cfg *watchTestConfig) Validate() error {
+	if cfg.Port <= 0 {
+		return errors.New("port has to be positive")
+	}
+
+	return nil
+}
+
+func TestWatcher(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("Port: 8080\n"), ModTime: time.Unix(1, 0)},
+	}
+
+	watcher, err := NewWatcher[watchTestConfig](WithFS(fileSystem), WithEnv(map[string]string{}), WithoutDotenv())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	initialConfig := watcher.Config()
+	if initialConfig.Port != 8080 {
+		t.Fatalf("expected port 8080, got %d", initialConfig.Port)
+	}
+
+	// nothing changed, nothing reloaded
+	if err := watcher.reloadIfChanged(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if watcher.Config() != initialConfig {
+		t.Fatal("expected config to not be reloaded without changes")
+	}
+
+	// invalid yaml keeps the old config
+	fileSystem["config.yaml"] = &fstest.MapFile{Data: []byte("Port: [\n"), ModTime: time.Unix(2, 0)}
+	if err := watcher.reloadIfChanged(); err == nil {
+		t.Fatal("expected error for invalid yaml, got none")
+	}
+	if watcher.Config() != initialConfig {
+		t.Fatal("expected old config to be kept after a failed reload")
+	}
+
+	// failing validation keeps the old config
+	fileSystem["config.yaml"] = &fstest.MapFile{Data: []byte("Port: -1\n"), ModTime: time.Unix(3, 0)}
+	if err := watcher.reloadIfChanged(); err == nil {
+		t.Fatal("expected error for invalid port, got none")
+	}
+	if watcher.Config() != initialConfig {
+		t.Fatal("expected old config to be kept after a failed validation")
+	}
+
+	fileSystem["config.yaml"] = &fstest.MapFile{Data: []byte("Port: 9090\nMode: prod\n"), ModTime: time.Unix(4, 0)}
+	if err := watcher.reloadIfChanged(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg := watcher.Config(); cfg.Port != 9090 || cfg.Mode != "prod" {
+		t.Fatalf("expected reloaded config, got %+v", cfg)
+	}
+	if initialConfig.Port != 8080 {
+		t.Fatal("expected the previously published config to stay untouched")
+	}
+}
+
+func TestWatcherPicksUpNewConfigFile(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{}
+
+	watcher, err := NewWatcher[watchTestConfig](WithFS(fileSystem), WithEnv(map[string]string{}), WithoutDotenv())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if watcher.Config().Port != 80 {
+		t.Fatalf("expected default port, got %d", watcher.Config().Port)
+	}
+
+	fileSystem["config/config.yml"] = &fstest.MapFile{Data: []byte("Port: 8080\n")}
+	if err := watcher.reloadIfChanged(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if watcher.Config().Port != 8080 {
+		t.Fatalf("expected port of the new config file, got %d", watcher.Config().Port)
+	}
+}
+
+func TestWatcherReplaysReaders(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("Port: 8080\n"), ModTime: time.Unix(1, 0)},
+	}
+
+	watcher, err := NewWatcher[watchTestConfig](WithFS(fileSystem), WithYamlFile("config.yaml"), WithEnv(map[string]string{}),
+		WithYamlReader(strings.NewReader("Mode: fromYamlReader\n")), WithDotenvReader(strings.NewReader("Port=9090\n")),
+		WithReadMode(ReadModeYamlThenEnv))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the readers are drained by the first read, but their content is kept for every reload
+	for range 2 {
+		if err := watcher.Reload(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if cfg := watcher.Config(); cfg.Mode != "fromYamlReader" || cfg.Port != 9090 {
+			t.Errorf("expected reader values to survive the reload, got %+v", cfg)
+		}
+	}
+}
+
+func TestWatcherInitialError(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("Port: 0\n")},
+	}
+
+	if _, err := NewWatcher[watchTestConfig](WithFS(fileSystem), WithEnv(map[string]string{}), WithoutDotenv()); err == nil {
+		t.Fatal("expected error for invalid initial config, got none")
+	}
+}
+
+func TestWatcherRun(t *testing.T) {
+	t.Parallel()
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte("Port: 8080\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	watcher, err := NewWatcher[watchTestConfig](WithYamlFile(configPath), WithEnv(map[string]string{}), WithoutDotenv())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	reloaded := make(chan *watchTestConfig, 1)
+	watcher.Interval = 10 * time.Millisecond
+	watcher.OnReload = func(cfg *watchTestConfig) {
+		reloaded <- cfg
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	runErr := make(chan error, 1)
+	go func() {
+		runErr <- watcher.Run(ctx)
+	}()
+
+	if err := os.WriteFile(configPath, []byte("Port: 10000\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case cfg := <-reloaded:
+		if cfg.Port != 10000 {
+			t.Errorf("expected port 10000, got %d", cfg.Port)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected config to be reloaded")
+	}
+
+	cancel()
+	if err := <-runErr; !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+type restartTestConfig struct {
+	Port     int    `default:"80" reload:"false"`
+	Password string `default:"initial" mask:"true" reload:"false"`
+	Mode     string `default:"dev"`
+}
+
+func TestWatcherRejectsRestartRequiredChanges(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("Port: 8080\n"), ModTime: time.Unix(1, 0)},
+	}
+
+	watcher, err := NewWatcher[restartTestConfig](WithFS(fileSystem), WithEnv(map[string]string{}), WithoutDotenv())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	initialConfig := watcher.Config()
+
+	fileSystem["config.yaml"] = &fstest.MapFile{Data: []byte("Port: 9090\nMode: prod\n"), ModTime: time.Unix(2, 0)}
+	err = watcher.reloadIfChanged()
+	if err == nil || !strings.Contains(err.Error(), "Port") {
+		t.Fatalf("expected reload to be rejected because of Port, got %v", err)
+	}
+	if watcher.Config() != initialConfig {
+		t.Fatal("expected old config to be kept after a rejected reload")
+	}
+
+	// fields allowing a reload are still reloaded
+	fileSystem["config.yaml"] = &fstest.MapFile{Data: []byte("Port: 8080\nMode: prod\n"), ModTime: time.Unix(3, 0)}
+	if err := watcher.reloadIfChanged(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if watcher.Config().Mode != "prod" {
+		t.Errorf("expected Mode to be reloaded, got %s", watcher.Config().Mode)
+	}
+}
+
+func TestWatcherPinsRestartRequiredChanges(t *testing.T) {
+	t.Parallel()
+
+	fileSystem := fstest.MapFS{
+		"config.yaml": {Data: []byte("Port: 8080\n"), ModTime: time.Unix(1, 0)},
+	}
+
+	watcher, err := NewWatcher[restartTestConfig](WithFS(fileSystem), WithEnv(map[string]string{}), WithoutDotenv())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var warnings []string
+	watcher.RestartRequired = PinRestartRequired
+	watcher.OnWarning = func(warning string) {
+		warnings = append(warnings, warning)
+	}
+
+	fileSystem["config.yaml"] = &fstest.MapFile{Data: []byte("Port: 9090\nPassword: newSecret\nMode: prod\n"), ModTime: time.Unix(2, 0)}
+	if err := watcher.reloadIfChanged(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	cfg := watcher.Config()
+	if cfg.Port != 8080 || cfg.Password != "initial" || cfg.Mode != "prod" {
+		t.Errorf("expected Port and Password to be pinned and Mode to be reloaded, got %+v", cfg)
+	}
+
+	if len(warnings) != 2 || !strings.Contains(warnings[0], "Port") {
+		t.Fatalf("expected a warning for Port and Password, got %v", warnings)
+	}
+	if strings.Contains(warnings[1], "newSecret") || strings.Contains(warnings[1], "initial") {
+		t.Errorf("expected password warning to be masked, got %s", warnings[1])
+	}
+}